@@ -2,9 +2,19 @@ package main
 
 import (
 	"CodeRewievService/internal/bootstrap"
+	"fmt"
+	"os"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == bootstrap.ApplyManifestCommand {
+		if err := bootstrap.RunApplyManifest(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	service := bootstrap.InitApplication()
 	if err := service.Run(); err != nil {
 		panic(err)
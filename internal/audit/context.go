@@ -0,0 +1,31 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+const (
+	ActorHeader    = "X-Actor"
+	AnonymousActor = "anonymous"
+)
+
+type contextKey struct{}
+
+// WithActor attaches the identity that changes state on behalf of the request or background job.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, contextKey{}, actor)
+}
+
+func Actor(ctx context.Context) string {
+	if actor, ok := ctx.Value(contextKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return AnonymousActor
+}
+
+// RequestID returns the ID assigned by chi's RequestID middleware; it is empty outside HTTP requests.
+func RequestID(ctx context.Context) string {
+	return middleware.GetReqID(ctx)
+}
@@ -0,0 +1,88 @@
+package bootstrap
+
+import (
+	"CodeRewievService/internal/audit"
+	"CodeRewievService/internal/scheduler"
+	"context"
+	"time"
+)
+
+const (
+	defaultSLACheckInterval             = time.Minute
+	defaultNotificationDispatchInterval = 5 * time.Second
+	defaultWebhookDeliveryInterval      = 5 * time.Second
+	defaultScheduledJobsInterval        = 30 * time.Second
+	defaultLoadImbalanceCheckInterval   = time.Hour
+	defaultLoadImbalanceThreshold       = 0.4
+
+	slaEscalationActor = "system:review-sla-escalation"
+)
+
+func (app *Application) initializeBackgroundJobs(svcs *servicesRegistry) []BackgroundJob {
+	slaEscalation := scheduler.NewPeriodicJob(
+		"review-sla-escalation",
+		app.durationFromEnv("SLA_CHECK_INTERVAL", defaultSLACheckInterval),
+		func(ctx context.Context) error {
+			escalated, err := svcs.sla.EscalateOverdueReviews(audit.WithActor(ctx, slaEscalationActor), time.Now())
+			if escalated > 0 {
+				app.logger.Info("Escalated overdue reviews", "count", escalated)
+			}
+			return err
+		},
+		app.logger,
+	)
+
+	notificationDispatch := scheduler.NewPeriodicJob(
+		"notification-dispatch",
+		app.durationFromEnv("NOTIFICATION_DISPATCH_INTERVAL", defaultNotificationDispatchInterval),
+		func(ctx context.Context) error {
+			sent, err := svcs.notification.DispatchPending(ctx, time.Now())
+			if sent > 0 {
+				app.logger.Info("Dispatched notifications", "count", sent)
+			}
+			return err
+		},
+		app.logger,
+	)
+
+	webhookDelivery := scheduler.NewPeriodicJob(
+		"webhook-delivery",
+		app.durationFromEnv("WEBHOOK_DELIVERY_INTERVAL", defaultWebhookDeliveryInterval),
+		func(ctx context.Context) error {
+			delivered, err := svcs.webhook.DeliverPending(ctx, time.Now())
+			if delivered > 0 {
+				app.logger.Info("Delivered webhooks", "count", delivered)
+			}
+			return err
+		},
+		app.logger,
+	)
+
+	scheduledJobs := scheduler.NewPeriodicJob(
+		"scheduled-jobs",
+		app.durationFromEnv("SCHEDULED_JOBS_INTERVAL", defaultScheduledJobsInterval),
+		func(ctx context.Context) error {
+			executed, err := svcs.scheduledJob.RunDue(ctx, time.Now())
+			if executed > 0 {
+				app.logger.Info("Executed scheduled jobs", "count", executed)
+			}
+			return err
+		},
+		app.logger,
+	)
+
+	loadImbalanceCheck := scheduler.NewPeriodicJob(
+		"review-load-imbalance-check",
+		app.durationFromEnv("LOAD_IMBALANCE_CHECK_INTERVAL", defaultLoadImbalanceCheckInterval),
+		func(ctx context.Context) error {
+			raised, err := svcs.statistics.CheckLoadImbalance(ctx, time.Now())
+			if raised > 0 {
+				app.logger.Info("Raised review load imbalance alerts", "count", raised)
+			}
+			return err
+		},
+		app.logger,
+	)
+
+	return []BackgroundJob{slaEscalation, notificationDispatch, webhookDelivery, scheduledJobs, loadImbalanceCheck}
+}
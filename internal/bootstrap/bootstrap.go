@@ -3,11 +3,13 @@ package bootstrap
 import (
 	"CodeRewievService/internal/controllers"
 	"CodeRewievService/internal/database"
+	"CodeRewievService/internal/events"
 	"context"
 	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
+	"strconv"
 	"sync"
 	"syscall"
 	"time"
@@ -30,7 +32,9 @@ type Application struct {
 }
 
 type Dependencies struct {
-	server Server
+	server         Server
+	backgroundJobs []BackgroundJob
+	events         *events.Bus
 }
 
 type applicationState struct {
@@ -81,7 +85,12 @@ func (app *Application) initializeDependencies() {
 	db := database.InitializeConnection()
 	config := app.loadServerConfig()
 
-	app.dependencies.server = controllers.NewHTTPServer(app.logger, db, config.address, config.port)
+	repos := initializeRepositories(db)
+	svcs := app.initializeServices(repos)
+
+	app.dependencies.server = controllers.NewHTTPServer(app.logger, svcs.controllerServices(), config.address, config.port)
+	app.dependencies.backgroundJobs = app.initializeBackgroundJobs(svcs)
+	app.dependencies.events = svcs.events
 	app.logger.Info("Dependencies initialized successfully", "address", config.address, "port", config.port)
 }
 
@@ -125,6 +134,11 @@ func (app *Application) start() error {
 	app.state.wg.Add(1)
 	go app.runServer()
 
+	for _, job := range app.dependencies.backgroundJobs {
+		app.state.wg.Add(1)
+		go app.runBackgroundJob(job)
+	}
+
 	app.setStateRunning(true)
 	app.logger.Info("Application components started successfully")
 
@@ -140,6 +154,13 @@ func (app *Application) runServer() {
 	}
 }
 
+func (app *Application) runBackgroundJob(job BackgroundJob) {
+	defer app.state.wg.Done()
+
+	app.logger.Debug("Starting background job", "job", job.Name())
+	job.Run(app.ctx)
+}
+
 func (app *Application) stop() error {
 	app.state.mu.Lock()
 	defer app.state.mu.Unlock()
@@ -157,6 +178,7 @@ func (app *Application) stop() error {
 	}
 
 	app.waitForGoroutines()
+	app.waitForEventHandlers()
 	app.setStateRunning(false)
 
 	close(app.shutdownSignal)
@@ -195,6 +217,15 @@ func (app *Application) waitForGoroutines() {
 	}
 }
 
+func (app *Application) waitForEventHandlers() {
+	ctx, cancel := context.WithTimeout(context.Background(), goroutineWaitTimeout)
+	defer cancel()
+
+	if err := app.dependencies.events.Wait(ctx); err != nil {
+		app.logger.Warn("Domain event handlers did not finish before shutdown", "error", err)
+	}
+}
+
 func (app *Application) setupShutdownHandlers() {
 	signal.Notify(app.shutdownSignal, os.Interrupt, syscall.SIGTERM)
 }
@@ -217,6 +248,37 @@ func (app *Application) setStateRunning(running bool) {
 	app.state.isRunning = running
 }
 
+func (app *Application) durationFromEnv(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		app.logger.Warn("Invalid duration value, using default", "key", key, "default", defaultValue, "error", err)
+		return defaultValue
+	}
+
+	return duration
+}
+
+// floatFromEnv accepts values in (0, 1], the range of the ratios it is used for.
+func (app *Application) floatFromEnv(key string, defaultValue float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil || parsed <= 0 || parsed > 1 {
+		app.logger.Warn("Invalid ratio value, using default", "key", key, "default", defaultValue, "error", err)
+		return defaultValue
+	}
+
+	return parsed
+}
+
 func parseInt(s string) (int, error) {
 	var result int
 	_, err := fmt.Sscanf(s, "%d", &result)
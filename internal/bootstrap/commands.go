@@ -0,0 +1,78 @@
+package bootstrap
+
+import (
+	"CodeRewievService/internal/audit"
+	"CodeRewievService/internal/database"
+	"CodeRewievService/internal/manifest"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/joho/godotenv"
+)
+
+const (
+	ApplyManifestCommand = "apply-manifest"
+
+	applyManifestActor = "cli:" + ApplyManifestCommand
+)
+
+// RunApplyManifest is the CLI counterpart of POST /team/manifest: it applies a manifest file and prints the diff.
+func RunApplyManifest(args []string) error {
+	flags := flag.NewFlagSet(ApplyManifestCommand, flag.ContinueOnError)
+	file := flags.String("file", "", "path to the YAML or CSV manifest")
+	format := flags.String("format", "", "manifest format: yaml or csv (detected from the file extension by default)")
+	dryRun := flags.Bool("dry-run", false, "compute the diff without changing anything")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *file == "" {
+		return errors.New("-file is required")
+	}
+
+	if *format == "" {
+		*format = manifestFormatFromPath(*file)
+	}
+
+	reader, err := os.Open(*file)
+	if err != nil {
+		return fmt.Errorf("failed to open manifest: %w", err)
+	}
+	defer reader.Close()
+
+	app := InitApplication()
+	if err := godotenv.Load(); err != nil {
+		app.logger.Warn("Failed to load .env file, using environment variables", "error", err)
+	}
+
+	svcs := app.initializeServices(initializeRepositories(database.InitializeConnection()))
+
+	diff, err := svcs.manifest.ApplyManifest(audit.WithActor(context.Background(), applyManifestActor), *format, reader, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	waitCtx, cancel := context.WithTimeout(context.Background(), goroutineWaitTimeout)
+	defer cancel()
+	if err := svcs.events.Wait(waitCtx); err != nil {
+		app.logger.Warn("Domain event handlers did not finish", "error", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(diff)
+}
+
+func manifestFormatFromPath(path string) string {
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return manifest.FormatCSV
+	}
+	return manifest.FormatYAML
+}
@@ -0,0 +1,203 @@
+package bootstrap
+
+import (
+	"CodeRewievService/internal/controllers"
+	"CodeRewievService/internal/events"
+	"CodeRewievService/internal/integrations"
+	"CodeRewievService/internal/notifications"
+	"CodeRewievService/internal/repository"
+	"CodeRewievService/internal/services"
+	"CodeRewievService/internal/webhooks"
+	"os"
+
+	"gorm.io/gorm"
+)
+
+const defaultSMTPPort = 25
+
+type repositoriesRegistry struct {
+	user         *repository.UserRepository
+	team         *repository.TeamRepository
+	pullRequest  *repository.PullRequestRepository
+	statistics   *repository.StatisticsRepository
+	sla          *repository.SLARepository
+	calendar     *repository.CalendarRepository
+	notification *repository.NotificationRepository
+	webhook      *repository.WebhookRepository
+	integration  *repository.IntegrationRepository
+	audit        *repository.AuditRepository
+	scheduledJob *repository.ScheduledJobRepository
+}
+
+type servicesRegistry struct {
+	user         *services.UserService
+	team         *services.TeamService
+	pullRequest  *services.PullRequestService
+	statistics   *services.StatisticsService
+	sla          *services.SLAService
+	calendar     *services.CalendarService
+	notification *services.NotificationService
+	webhook      *services.WebhookService
+	integration  *services.IntegrationService
+	manifest     *services.ManifestService
+	audit        *services.AuditService
+	membership   *services.MembershipService
+	scheduledJob *services.ScheduledJobService
+	events       *events.Bus
+}
+
+func initializeRepositories(db *gorm.DB) *repositoriesRegistry {
+	return &repositoriesRegistry{
+		user:         repository.NewUserRepository(db),
+		team:         repository.NewTeamRepository(db),
+		pullRequest:  repository.NewPullRequestRepository(db),
+		statistics:   repository.NewStatisticsRepository(db),
+		sla:          repository.NewSLARepository(db),
+		calendar:     repository.NewCalendarRepository(db),
+		notification: repository.NewNotificationRepository(db),
+		webhook:      repository.NewWebhookRepository(db),
+		integration:  repository.NewIntegrationRepository(db),
+		audit:        repository.NewAuditRepository(db),
+		scheduledJob: repository.NewScheduledJobRepository(db),
+	}
+}
+
+func (app *Application) initializeServices(repos *repositoriesRegistry) *servicesRegistry {
+	calendars := services.NewCalendarService(repos.calendar, repos.team)
+	notification := services.NewNotificationService(
+		repos.notification,
+		repos.user,
+		app.initializeNotificationSenders(),
+		services.DefaultRetryPolicy(),
+		app.logger,
+	)
+	webhook := services.NewWebhookService(
+		repos.webhook,
+		repos.pullRequest,
+		webhooks.NewClient(nil),
+		services.DefaultRetryPolicy(),
+		app.logger,
+	)
+	auditLog := services.NewAuditService(repos.audit)
+	bus := events.NewBus(app.logger)
+	app.registerEventSubscribers(bus, webhook)
+
+	pullRequest := services.NewPullRequestService(
+		repos.pullRequest,
+		repos.user,
+		repos.team,
+		calendars,
+		notification,
+		auditLog,
+		bus,
+	)
+
+	user := services.NewUserService(repos.user, repos.team, pullRequest, calendars, notification, auditLog, bus, app.logger)
+	team := services.NewTeamService(repos.team, repos.pullRequest, pullRequest, notification, auditLog, bus, app.logger)
+
+	statistics := services.NewStatisticsService(
+		repos.statistics,
+		calendars,
+		bus,
+		app.floatFromEnv("LOAD_IMBALANCE_THRESHOLD", defaultLoadImbalanceThreshold),
+	)
+
+	return &servicesRegistry{
+		user:         user,
+		team:         team,
+		pullRequest:  pullRequest,
+		statistics:   statistics,
+		sla:          services.NewSLAService(repos.sla, repos.team, pullRequest, calendars, notification, app.logger),
+		calendar:     calendars,
+		notification: notification,
+		webhook:      webhook,
+		integration: services.NewIntegrationService(
+			repos.integration,
+			repos.user,
+			pullRequest,
+			app.initializeGitHubClient(),
+			services.IntegrationConfig{
+				GitHubWebhookSecret: os.Getenv("GITHUB_WEBHOOK_SECRET"),
+				GitLabWebhookToken:  os.Getenv("GITLAB_WEBHOOK_TOKEN"),
+			},
+			app.logger,
+		),
+		manifest:   services.NewManifestService(repos.team, repos.user, pullRequest, notification, auditLog, bus, app.logger),
+		audit:      auditLog,
+		membership: services.NewMembershipService(repos.team, repos.user, pullRequest, auditLog, bus),
+		scheduledJob: services.NewScheduledJobService(
+			repos.scheduledJob,
+			repos.user,
+			repos.team,
+			user,
+			team,
+			auditLog,
+			app.logger,
+		),
+		events: bus,
+	}
+}
+
+func (app *Application) initializeNotificationSenders() *notifications.Registry {
+	senders := []notifications.Sender{
+		notifications.NewWebhookSender(nil),
+		notifications.NewSlackSender(nil),
+	}
+
+	smtpHost := os.Getenv("SMTP_HOST")
+	if smtpHost == "" {
+		app.logger.Warn("SMTP_HOST is not set, email notifications are disabled")
+		return notifications.NewRegistry(senders...)
+	}
+
+	smtpPort := defaultSMTPPort
+	if portStr := os.Getenv("SMTP_PORT"); portStr != "" {
+		if parsedPort, err := parseInt(portStr); err == nil {
+			smtpPort = parsedPort
+		} else {
+			app.logger.Warn("Invalid SMTP_PORT value, using default", "port", smtpPort, "error", err)
+		}
+	}
+
+	senders = append(senders, notifications.NewSMTPSender(notifications.SMTPConfig{
+		Host:     smtpHost,
+		Port:     smtpPort,
+		From:     os.Getenv("SMTP_FROM"),
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+	}))
+
+	return notifications.NewRegistry(senders...)
+}
+
+// initializeGitHubClient returns nil when neither a token nor an API base URL is configured,
+// which turns off posting review requests back to GitHub.
+func (app *Application) initializeGitHubClient() *integrations.GitHubClient {
+	baseURL := os.Getenv("GITHUB_API_BASE_URL")
+	token := os.Getenv("GITHUB_TOKEN")
+
+	if baseURL == "" && token == "" {
+		app.logger.Warn("GITHUB_TOKEN is not set, review requests are not posted back to GitHub")
+		return nil
+	}
+
+	return integrations.NewGitHubClient(baseURL, token, nil)
+}
+
+func (svcs *servicesRegistry) controllerServices() controllers.Services {
+	return controllers.Services{
+		User:         svcs.user,
+		Team:         svcs.team,
+		PullRequest:  svcs.pullRequest,
+		Statistics:   svcs.statistics,
+		SLA:          svcs.sla,
+		Calendar:     svcs.calendar,
+		Notification: svcs.notification,
+		Webhook:      svcs.webhook,
+		Integration:  svcs.integration,
+		Manifest:     svcs.manifest,
+		Audit:        svcs.audit,
+		Membership:   svcs.membership,
+		ScheduledJob: svcs.scheduledJob,
+	}
+}
@@ -0,0 +1,26 @@
+package bootstrap
+
+import (
+	"CodeRewievService/internal/audit"
+	"CodeRewievService/internal/events"
+	"CodeRewievService/internal/services"
+	"context"
+)
+
+// registerEventSubscribers is where side effects hook into domain events published by the services.
+// Side effects that must survive a crash, like outgoing webhooks, subscribe in the transaction of the change;
+// the rest react after commit.
+func (app *Application) registerEventSubscribers(bus *events.Bus, webhook *services.WebhookService) {
+	bus.SubscribeInTx(events.AllEvents, "outgoing-webhooks", webhook.EnqueueEventInTx)
+	bus.SubscribeAsync(events.AllEvents, "domain-event-log", app.logDomainEvent)
+}
+
+func (app *Application) logDomainEvent(ctx context.Context, event events.Event) error {
+	app.logger.Debug("Domain event published",
+		"event", event.Name(),
+		"actor", audit.Actor(ctx),
+		"requestID", audit.RequestID(ctx),
+		"payload", event,
+	)
+	return nil
+}
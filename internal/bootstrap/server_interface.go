@@ -9,3 +9,8 @@ type Server interface {
 	Stop(ctx context.Context, timeout time.Duration) error
 	Start(ctx context.Context) error
 }
+
+type BackgroundJob interface {
+	Name() string
+	Run(ctx context.Context)
+}
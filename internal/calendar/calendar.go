@@ -0,0 +1,169 @@
+package calendar
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	// maxScannedDays bounds day-by-day walks so a calendar without reachable working time cannot loop forever.
+	maxScannedDays = 366 * 20
+)
+
+var (
+	ErrNoWorkingDays      = errors.New("calendar must contain at least one working day")
+	ErrInvalidWorkingDays = errors.New("working day must start before it ends and fit into 24 hours")
+)
+
+type Calendar struct {
+	location   *time.Location
+	workDays   map[time.Weekday]bool
+	dayStart   time.Duration
+	dayEnd     time.Duration
+	holidays   map[string]bool
+	alwaysOpen bool
+}
+
+type Holiday struct {
+	Date time.Time
+	Name string
+}
+
+// AlwaysOpen is the calendar of teams without configured business hours: every moment counts.
+func AlwaysOpen() *Calendar {
+	return &Calendar{
+		location:   time.UTC,
+		alwaysOpen: true,
+	}
+}
+
+func New(
+	location *time.Location,
+	workDays []time.Weekday,
+	dayStart time.Duration,
+	dayEnd time.Duration,
+	holidays []time.Time,
+) (*Calendar, error) {
+	if len(workDays) == 0 {
+		return nil, ErrNoWorkingDays
+	}
+
+	if dayStart < 0 || dayEnd > 24*time.Hour || dayStart >= dayEnd {
+		return nil, ErrInvalidWorkingDays
+	}
+
+	if location == nil {
+		location = time.UTC
+	}
+
+	cal := &Calendar{
+		location: location,
+		workDays: make(map[time.Weekday]bool, len(workDays)),
+		dayStart: dayStart,
+		dayEnd:   dayEnd,
+		holidays: make(map[string]bool, len(holidays)),
+	}
+
+	for _, day := range workDays {
+		cal.workDays[day] = true
+	}
+
+	for _, holiday := range holidays {
+		cal.holidays[holiday.Format(dateLayout)] = true
+	}
+
+	return cal, nil
+}
+
+// BusinessElapsed returns the working time between from and to.
+func (c *Calendar) BusinessElapsed(from, to time.Time) time.Duration {
+	if !to.After(from) {
+		return 0
+	}
+
+	if c.alwaysOpen {
+		return to.Sub(from)
+	}
+
+	var elapsed time.Duration
+	day := c.startOfDay(from)
+
+	for i := 0; i < maxScannedDays && day.Before(to); i++ {
+		if windowStart, windowEnd, ok := c.workingWindow(day); ok {
+			start := latest(windowStart, from)
+			end := earliest(windowEnd, to)
+			if end.After(start) {
+				elapsed += end.Sub(start)
+			}
+		}
+		day = c.nextDay(day)
+	}
+
+	return elapsed
+}
+
+// AddBusinessTime returns the moment when the given amount of working time has passed since from.
+func (c *Calendar) AddBusinessTime(from time.Time, duration time.Duration) time.Time {
+	if c.alwaysOpen || duration <= 0 {
+		return from.Add(duration)
+	}
+
+	remaining := duration
+	day := c.startOfDay(from)
+
+	for i := 0; i < maxScannedDays; i++ {
+		if windowStart, windowEnd, ok := c.workingWindow(day); ok && windowEnd.After(from) {
+			start := latest(windowStart, from)
+			available := windowEnd.Sub(start)
+			if available >= remaining {
+				return start.Add(remaining)
+			}
+			remaining -= available
+		}
+		day = c.nextDay(day)
+	}
+
+	return from.Add(duration)
+}
+
+func (c *Calendar) IsHoliday(date time.Time) bool {
+	return c.holidays[date.In(c.location).Format(dateLayout)]
+}
+
+func (c *Calendar) workingWindow(day time.Time) (time.Time, time.Time, bool) {
+	if !c.workDays[day.Weekday()] || c.holidays[day.Format(dateLayout)] {
+		return time.Time{}, time.Time{}, false
+	}
+
+	return c.atOffset(day, c.dayStart), c.atOffset(day, c.dayEnd), true
+}
+
+func (c *Calendar) atOffset(day time.Time, offset time.Duration) time.Time {
+	hours := int(offset / time.Hour)
+	minutes := int((offset % time.Hour) / time.Minute)
+	return time.Date(day.Year(), day.Month(), day.Day(), hours, minutes, 0, 0, c.location)
+}
+
+func (c *Calendar) startOfDay(moment time.Time) time.Time {
+	local := moment.In(c.location)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, c.location)
+}
+
+func (c *Calendar) nextDay(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, c.location)
+}
+
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earliest(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
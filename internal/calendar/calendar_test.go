@@ -0,0 +1,148 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+var weekdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+func mustLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("load location %s: %v", name, err)
+	}
+	return location
+}
+
+func mustCalendar(t *testing.T, location *time.Location, workDays []time.Weekday, start, end time.Duration, holidays ...time.Time) *Calendar {
+	t.Helper()
+	cal, err := New(location, workDays, start, end, holidays)
+	if err != nil {
+		t.Fatalf("new calendar: %v", err)
+	}
+	return cal
+}
+
+func TestNewRejectsInvalidWorkingDays(t *testing.T) {
+	tests := []struct {
+		name     string
+		workDays []time.Weekday
+		start    time.Duration
+		end      time.Duration
+		want     error
+	}{
+		{"no working days", nil, 9 * time.Hour, 18 * time.Hour, ErrNoWorkingDays},
+		{"window crossing midnight", weekdays, 22 * time.Hour, 6 * time.Hour, ErrInvalidWorkingDays},
+		{"empty window", weekdays, 9 * time.Hour, 9 * time.Hour, ErrInvalidWorkingDays},
+		{"window past end of day", weekdays, 9 * time.Hour, 25 * time.Hour, ErrInvalidWorkingDays},
+		{"negative start", weekdays, -time.Hour, 18 * time.Hour, ErrInvalidWorkingDays},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(time.UTC, tt.workDays, tt.start, tt.end, nil); err != tt.want {
+				t.Fatalf("got error %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBusinessElapsed(t *testing.T) {
+	berlin := mustLocation(t, "Europe/Berlin")
+	officeHours := mustCalendar(t, time.UTC, weekdays, 9*time.Hour, 18*time.Hour,
+		time.Date(2025, time.May, 1, 0, 0, 0, 0, time.UTC))
+	untilMidnight := mustCalendar(t, time.UTC, weekdays, 20*time.Hour, 24*time.Hour)
+	wholeDays := mustCalendar(t, berlin, []time.Weekday{time.Saturday, time.Sunday}, 0, 24*time.Hour)
+	berlinOffice := mustCalendar(t, berlin, weekdays, 9*time.Hour, 18*time.Hour)
+
+	utc := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2025, month, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name string
+		cal  *Calendar
+		from time.Time
+		to   time.Time
+		want time.Duration
+	}{
+		{"within one working day", officeHours, utc(time.March, 3, 10, 0), utc(time.March, 3, 12, 30), 150 * time.Minute},
+		{"to before from", officeHours, utc(time.March, 3, 12, 0), utc(time.March, 3, 10, 0), 0},
+		{"equal bounds", officeHours, utc(time.March, 3, 12, 0), utc(time.March, 3, 12, 0), 0},
+		{"before the working day starts", officeHours, utc(time.March, 3, 6, 0), utc(time.March, 3, 9, 0), 0},
+		{"clipped to working hours", officeHours, utc(time.March, 3, 7, 0), utc(time.March, 3, 20, 0), 9 * time.Hour},
+		{"overnight", officeHours, utc(time.March, 3, 17, 0), utc(time.March, 4, 10, 0), 2 * time.Hour},
+		{"over a weekend", officeHours, utc(time.March, 7, 17, 0), utc(time.March, 10, 10, 0), 2 * time.Hour},
+		{"entirely on a weekend", officeHours, utc(time.March, 8, 10, 0), utc(time.March, 9, 16, 0), 0},
+		{"full working week", officeHours, utc(time.March, 3, 0, 0), utc(time.March, 10, 0, 0), 45 * time.Hour},
+		{"holiday is skipped", officeHours, utc(time.April, 30, 17, 0), utc(time.May, 2, 10, 0), 2 * time.Hour},
+		{"window until midnight", untilMidnight, utc(time.March, 3, 23, 0), utc(time.March, 4, 21, 0), 2 * time.Hour},
+		{"always open", AlwaysOpen(), utc(time.March, 8, 10, 0), utc(time.March, 9, 16, 0), 30 * time.Hour},
+		{
+			"spring forward day is one hour shorter",
+			wholeDays,
+			time.Date(2025, time.March, 29, 0, 0, 0, 0, berlin),
+			time.Date(2025, time.March, 31, 0, 0, 0, 0, berlin),
+			47 * time.Hour,
+		},
+		{
+			"fall back day is one hour longer",
+			wholeDays,
+			time.Date(2025, time.October, 25, 0, 0, 0, 0, berlin),
+			time.Date(2025, time.October, 27, 0, 0, 0, 0, berlin),
+			49 * time.Hour,
+		},
+		{
+			"working hours follow the local clock across DST",
+			berlinOffice,
+			time.Date(2025, time.March, 28, 9, 0, 0, 0, berlin),
+			time.Date(2025, time.March, 31, 18, 0, 0, 0, berlin),
+			18 * time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cal.BusinessElapsed(tt.from, tt.to); got != tt.want {
+				t.Fatalf("BusinessElapsed(%s, %s) = %s, want %s", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddBusinessTime(t *testing.T) {
+	officeHours := mustCalendar(t, time.UTC, weekdays, 9*time.Hour, 18*time.Hour)
+
+	utc := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2025, month, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		from     time.Time
+		duration time.Duration
+		want     time.Time
+	}{
+		{"within the same day", utc(time.March, 3, 10, 0), 2 * time.Hour, utc(time.March, 3, 12, 0)},
+		{"exactly at the end of the day", utc(time.March, 3, 10, 0), 8 * time.Hour, utc(time.March, 3, 18, 0)},
+		{"rolls over to the next day", utc(time.March, 3, 17, 0), 2 * time.Hour, utc(time.March, 4, 10, 0)},
+		{"rolls over a weekend", utc(time.March, 7, 17, 0), 2 * time.Hour, utc(time.March, 10, 10, 0)},
+		{"starts before working hours", utc(time.March, 3, 6, 0), time.Hour, utc(time.March, 3, 10, 0)},
+		{"non-positive duration", utc(time.March, 8, 10, 0), 0, utc(time.March, 8, 10, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := officeHours.AddBusinessTime(tt.from, tt.duration)
+			if !got.Equal(tt.want) {
+				t.Fatalf("AddBusinessTime(%s, %s) = %s, want %s", tt.from, tt.duration, got, tt.want)
+			}
+			if elapsed := officeHours.BusinessElapsed(tt.from, got); tt.duration > 0 && elapsed != tt.duration {
+				t.Fatalf("BusinessElapsed of the result = %s, want %s", elapsed, tt.duration)
+			}
+		})
+	}
+}
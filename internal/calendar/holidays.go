@@ -0,0 +1,176 @@
+package calendar
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatICal = "ical"
+
+	icalDateLayout = "20060102"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported holidays file format")
+
+func ParseHolidays(format string, reader io.Reader) ([]Holiday, error) {
+	switch strings.ToLower(format) {
+	case FormatCSV:
+		return ParseHolidaysCSV(reader)
+	case FormatICal, "ics":
+		return ParseHolidaysICal(reader)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// ParseHolidaysCSV reads "date,name" rows with dates in YYYY-MM-DD form; a header row is skipped.
+func ParseHolidaysCSV(reader io.Reader) ([]Holiday, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	var holidays []Holiday
+	for line := 1; ; line++ {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read csv: %w", err)
+		}
+
+		if len(record) == 0 || strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+
+		date, err := time.Parse(dateLayout, strings.TrimSpace(record[0]))
+		if err != nil {
+			if line == 1 {
+				continue
+			}
+			return nil, fmt.Errorf("invalid date on line %d: %w", line, err)
+		}
+
+		holiday := Holiday{Date: date}
+		if len(record) > 1 {
+			holiday.Name = strings.TrimSpace(record[1])
+		}
+		holidays = append(holidays, holiday)
+	}
+
+	return holidays, nil
+}
+
+// ParseHolidaysICal reads all-day VEVENT entries; multi-day events expand into one holiday per day.
+func ParseHolidaysICal(reader io.Reader) ([]Holiday, error) {
+	lines, err := unfoldICalLines(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		holidays []Holiday
+		inEvent  bool
+		start    time.Time
+		end      time.Time
+		summary  string
+	)
+
+	for _, line := range lines {
+		name, value := splitICalProperty(line)
+
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			inEvent, start, end, summary = true, time.Time{}, time.Time{}, ""
+
+		case name == "END" && value == "VEVENT":
+			if !inEvent || start.IsZero() {
+				return nil, errors.New("ical event without DTSTART")
+			}
+			holidays = append(holidays, expandEvent(start, end, summary)...)
+			inEvent = false
+
+		case inEvent && name == "DTSTART":
+			if start, err = parseICalDate(value); err != nil {
+				return nil, err
+			}
+
+		case inEvent && name == "DTEND":
+			if end, err = parseICalDate(value); err != nil {
+				return nil, err
+			}
+
+		case inEvent && name == "SUMMARY":
+			summary = value
+		}
+	}
+
+	return holidays, nil
+}
+
+func unfoldICalLines(reader io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(reader)
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ical: %w", err)
+	}
+
+	return lines, nil
+}
+
+func splitICalProperty(line string) (string, string) {
+	colon := strings.Index(line, ":")
+	if colon < 0 {
+		return strings.ToUpper(line), ""
+	}
+
+	name := line[:colon]
+	if semicolon := strings.Index(name, ";"); semicolon >= 0 {
+		name = name[:semicolon]
+	}
+
+	return strings.ToUpper(name), strings.TrimSpace(line[colon+1:])
+}
+
+func parseICalDate(value string) (time.Time, error) {
+	if len(value) < len(icalDateLayout) {
+		return time.Time{}, fmt.Errorf("invalid ical date %q", value)
+	}
+
+	date, err := time.Parse(icalDateLayout, value[:len(icalDateLayout)])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid ical date %q: %w", value, err)
+	}
+
+	return date, nil
+}
+
+func expandEvent(start, end time.Time, summary string) []Holiday {
+	if !end.After(start) {
+		return []Holiday{{Date: start, Name: summary}}
+	}
+
+	var holidays []Holiday
+	for day := start; day.Before(end) && len(holidays) < maxScannedDays; day = day.AddDate(0, 0, 1) {
+		holidays = append(holidays, Holiday{Date: day, Name: summary})
+	}
+
+	return holidays
+}
@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"CodeRewievService/internal/models"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type AuditController struct {
+	service AuditService
+	logger  *slog.Logger
+}
+
+func NewAuditController(service AuditService, logger *slog.Logger) *AuditController {
+	return &AuditController{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (ctrl *AuditController) ListRecords(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := models.AuditFilter{
+		Actor:      query.Get("actor"),
+		Operation:  query.Get("operation"),
+		EntityType: query.Get("entity_type"),
+		EntityID:   query.Get("entity_id"),
+		RequestID:  query.Get("request_id"),
+	}
+
+	var err error
+	if filter.From, err = parseAuditTime(query.Get("from")); err != nil {
+		ctrl.sendErrorResponse(w, "from must be an RFC 3339 timestamp", http.StatusBadRequest)
+		return
+	}
+
+	if filter.To, err = parseAuditTime(query.Get("to")); err != nil {
+		ctrl.sendErrorResponse(w, "to must be an RFC 3339 timestamp", http.StatusBadRequest)
+		return
+	}
+
+	if value := query.Get("limit"); value != "" {
+		if filter.Limit, err = strconv.Atoi(value); err != nil {
+			ctrl.sendErrorResponse(w, "limit must be an integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	records, err := ctrl.service.List(filter)
+	if err != nil {
+		ctrl.logger.Error("Failed to list audit records", "error", err)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, models.ResponseAuditRecords{Records: records}, http.StatusOK)
+}
+
+func parseAuditTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+func (ctrl *AuditController) sendJSONResponse(w http.ResponseWriter, data interface{}, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		ctrl.logger.Error("Failed to encode JSON response", "error", err)
+	}
+}
+
+func (ctrl *AuditController) sendErrorResponse(w http.ResponseWriter, message string, statusCode int) {
+	ctrl.sendJSONResponse(w, models.Error{
+		Code:    "ERROR",
+		Message: message,
+	}, statusCode)
+}
@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"CodeRewievService/internal/calendar"
+	"CodeRewievService/internal/models"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+const maxHolidaysFileSize = 1 << 20
+
+type CalendarController struct {
+	service CalendarService
+	logger  *slog.Logger
+}
+
+func NewCalendarController(service CalendarService, logger *slog.Logger) *CalendarController {
+	return &CalendarController{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (ctrl *CalendarController) SetTeamCalendar(w http.ResponseWriter, r *http.Request) {
+	var req models.RequestSetTeamCalendar
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ctrl.sendErrorResponse(w, "Invalid request body", http.StatusBadRequest)
+		ctrl.logger.Error("Failed to decode request body", "error", err)
+		return
+	}
+
+	teamCalendar, err := ctrl.service.SetTeamCalendar(&req)
+	if errors.Is(err, models.ErrTeamNotFound) {
+		ctrl.sendNotFoundResponse(w)
+		return
+	}
+
+	if errors.Is(err, models.ErrInvalidCalendar) {
+		ctrl.sendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err != nil {
+		ctrl.logger.Error("Failed to set team calendar", "error", err, "teamName", req.TeamName)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, teamCalendar, http.StatusOK)
+}
+
+func (ctrl *CalendarController) GetTeamCalendar(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		ctrl.sendErrorResponse(w, "team_name parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	teamCalendar, err := ctrl.service.GetTeamCalendar(teamName)
+	if errors.Is(err, models.ErrTeamNotFound) {
+		ctrl.sendNotFoundResponse(w)
+		return
+	}
+
+	if err != nil {
+		ctrl.logger.Error("Failed to get team calendar", "error", err, "teamName", teamName)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, teamCalendar, http.StatusOK)
+}
+
+func (ctrl *CalendarController) ImportHolidays(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		ctrl.sendErrorResponse(w, "team_name parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = formatFromContentType(r.Header.Get("Content-Type"))
+	}
+
+	replace := r.URL.Query().Get("replace") == "true"
+	body := http.MaxBytesReader(w, r.Body, maxHolidaysFileSize)
+
+	imported, err := ctrl.service.ImportHolidays(teamName, format, body, replace)
+	if errors.Is(err, models.ErrTeamNotFound) {
+		ctrl.sendNotFoundResponse(w)
+		return
+	}
+
+	if errors.Is(err, models.ErrInvalidHolidaysFile) {
+		ctrl.sendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err != nil {
+		ctrl.logger.Error("Failed to import holidays", "error", err, "teamName", teamName)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, models.ResponseImportHolidays{
+		TeamName: teamName,
+		Imported: imported,
+	}, http.StatusOK)
+}
+
+func formatFromContentType(contentType string) string {
+	if strings.HasPrefix(contentType, "text/calendar") {
+		return calendar.FormatICal
+	}
+	return calendar.FormatCSV
+}
+
+func (ctrl *CalendarController) sendJSONResponse(w http.ResponseWriter, data interface{}, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		ctrl.logger.Error("Failed to encode JSON response", "error", err)
+	}
+}
+
+func (ctrl *CalendarController) sendErrorResponse(w http.ResponseWriter, message string, statusCode int) {
+	ctrl.sendJSONResponse(w, models.Error{
+		Code:    "ERROR",
+		Message: message,
+	}, statusCode)
+}
+
+func (ctrl *CalendarController) sendNotFoundResponse(w http.ResponseWriter) {
+	ctrl.sendJSONResponse(w, models.Error{
+		Code:    "NOT_FOUND",
+		Message: "resource not found",
+	}, http.StatusNotFound)
+}
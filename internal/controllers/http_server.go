@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"CodeRewievService/internal/audit"
 	"context"
 	"errors"
 	"fmt"
@@ -9,13 +10,8 @@ import (
 	"sync"
 	"time"
 
-	"gorm.io/gorm"
-
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
-
-	"CodeRewievService/internal/repository"
-	"CodeRewievService/internal/services"
 )
 
 const (
@@ -41,20 +37,27 @@ type serverConfig struct {
 }
 
 type controllersRegistry struct {
-	user        *UserController
-	team        *TeamController
-	pullRequest *PullRequestController
-	statistics  *StatisticsController
+	user         *UserController
+	team         *TeamController
+	pullRequest  *PullRequestController
+	statistics   *StatisticsController
+	sla          *SLAController
+	calendar     *CalendarController
+	notification *NotificationController
+	webhook      *WebhookController
+	integration  *IntegrationController
+	manifest     *ManifestController
+	audit        *AuditController
+	membership   *MembershipController
+	scheduledJob *ScheduledJobController
 }
 
-func NewHTTPServer(logger *slog.Logger, db *gorm.DB, address string, port int) *HTTPServer {
+func NewHTTPServer(logger *slog.Logger, svcs Services, address string, port int) *HTTPServer {
 	config := serverConfig{
 		address: normalizeAddress(address),
 		port:    normalizePort(port),
 	}
 
-	repos := initializeRepositories(db)
-	svcs := initializeServices(repos, logger)
 	ctrls := initializeControllers(svcs, logger)
 
 	return &HTTPServer{
@@ -118,6 +121,7 @@ func (s *HTTPServer) Stop(ctx context.Context, timeout time.Duration) error {
 func (s *HTTPServer) createRouter() *chi.Mux {
 	router := chi.NewRouter()
 	router.Use(middleware.RequestID)
+	router.Use(actorMiddleware)
 	router.Use(s.requestLoggingMiddleware)
 	s.registerAllRoutes(router)
 	return router
@@ -144,13 +148,26 @@ func (s *HTTPServer) registerAllRoutes(router *chi.Mux) {
 	s.registerTeamRoutes(router)
 	s.registerPullRequestRoutes(router)
 	s.registerStatisticsRoutes(router)
+	s.registerSLARoutes(router)
+	s.registerCalendarRoutes(router)
+	s.registerNotificationRoutes(router)
+	s.registerWebhookRoutes(router)
+	s.registerIntegrationRoutes(router)
+	s.registerManifestRoutes(router)
+	s.registerAuditRoutes(router)
+	s.registerMembershipRoutes(router)
+	s.registerScheduledJobRoutes(router)
 	s.logger.Info("All HTTP routes registered successfully")
 }
 
 func (s *HTTPServer) registerUserRoutes(router *chi.Mux) {
 	router.Route("/users", func(r chi.Router) {
 		r.Post("/setIsActive", s.controllers.user.SetUserIsActive)
+		r.Post("/bulkSetIsActive", s.controllers.user.BulkSetUserIsActive)
 		r.Get("/getReview", s.controllers.user.GetUserReview)
+		r.Get("/get", s.controllers.user.GetUser)
+		r.Get("/list", s.controllers.user.ListUsers)
+		r.Post("/update", s.controllers.user.UpdateUser)
 	})
 }
 
@@ -158,7 +175,13 @@ func (s *HTTPServer) registerTeamRoutes(router *chi.Mux) {
 	router.Route("/team", func(r chi.Router) {
 		r.Post("/add", s.controllers.team.CreateTeam)
 		r.Get("/get", s.controllers.team.GetTeam)
+		r.Post("/setParent", s.controllers.team.SetParent)
+		r.Post("/rename", s.controllers.team.RenameTeam)
+		r.Get("/list", s.controllers.team.ListTeams)
+		r.Post("/archive", s.controllers.team.ArchiveTeam)
+		r.Delete("/delete", s.controllers.team.DeleteTeam)
 		r.Post("/deactivate", s.controllers.team.MassDeactivateTeamUsers)
+		r.Post("/reactivate", s.controllers.team.ReactivateTeam)
 	})
 }
 
@@ -167,11 +190,90 @@ func (s *HTTPServer) registerPullRequestRoutes(router *chi.Mux) {
 		r.Post("/create", s.controllers.pullRequest.CreatePR)
 		r.Post("/merge", s.controllers.pullRequest.MergePR)
 		r.Post("/reassign", s.controllers.pullRequest.ReassignPR)
+		r.Post("/review", s.controllers.pullRequest.SubmitReview)
+		r.Get("/history", s.controllers.pullRequest.GetHistory)
 	})
 }
 
 func (s *HTTPServer) registerStatisticsRoutes(router *chi.Mux) {
 	router.Get("/statistics", s.controllers.statistics.GetAssignmentsStats)
+	router.Get("/statistics/fairness", s.controllers.statistics.GetFairness)
+	router.Get("/statistics/turnaround", s.controllers.statistics.GetTurnaround)
+	router.Get("/statistics/overview", s.controllers.statistics.GetOverview)
+}
+
+func (s *HTTPServer) registerSLARoutes(router *chi.Mux) {
+	router.Post("/team/sla", s.controllers.sla.SetTeamSLA)
+	router.Get("/team/sla", s.controllers.sla.GetTeamSLA)
+	router.Get("/pullRequests/overdue", s.controllers.sla.GetOverdueReviews)
+}
+
+func (s *HTTPServer) registerCalendarRoutes(router *chi.Mux) {
+	router.Route("/team/calendar", func(r chi.Router) {
+		r.Post("/", s.controllers.calendar.SetTeamCalendar)
+		r.Get("/", s.controllers.calendar.GetTeamCalendar)
+		r.Post("/holidays", s.controllers.calendar.ImportHolidays)
+	})
+}
+
+func (s *HTTPServer) registerNotificationRoutes(router *chi.Mux) {
+	router.Post("/users/notificationPreferences", s.controllers.notification.SetPreferences)
+	router.Get("/users/notificationPreferences", s.controllers.notification.GetPreferences)
+}
+
+func (s *HTTPServer) registerWebhookRoutes(router *chi.Mux) {
+	router.Route("/webhooks", func(r chi.Router) {
+		r.Post("/subscribe", s.controllers.webhook.Subscribe)
+		r.Get("/list", s.controllers.webhook.ListSubscriptions)
+		r.Post("/unsubscribe", s.controllers.webhook.Unsubscribe)
+		r.Get("/deliveries", s.controllers.webhook.ListDeliveries)
+		r.Post("/redeliver", s.controllers.webhook.Redeliver)
+	})
+}
+
+func (s *HTTPServer) registerManifestRoutes(router *chi.Mux) {
+	router.Post("/team/manifest", s.controllers.manifest.ApplyManifest)
+}
+
+func (s *HTTPServer) registerMembershipRoutes(router *chi.Mux) {
+	router.Route("/team/members", func(r chi.Router) {
+		r.Post("/add", s.controllers.membership.AddMember)
+		r.Post("/remove", s.controllers.membership.RemoveMember)
+		r.Post("/move", s.controllers.membership.MoveMember)
+	})
+}
+
+func (s *HTTPServer) registerAuditRoutes(router *chi.Mux) {
+	router.Get("/audit", s.controllers.audit.ListRecords)
+}
+
+func (s *HTTPServer) registerScheduledJobRoutes(router *chi.Mux) {
+	router.Route("/scheduledJobs", func(r chi.Router) {
+		r.Post("/create", s.controllers.scheduledJob.Schedule)
+		r.Post("/cancel", s.controllers.scheduledJob.Cancel)
+		r.Get("/get", s.controllers.scheduledJob.Get)
+		r.Get("/list", s.controllers.scheduledJob.List)
+	})
+}
+
+func (s *HTTPServer) registerIntegrationRoutes(router *chi.Mux) {
+	router.Route("/integrations", func(r chi.Router) {
+		r.Post("/github/webhook", s.controllers.integration.HandleGitHubWebhook)
+		r.Post("/gitlab/webhook", s.controllers.integration.HandleGitLabWebhook)
+		r.Post("/users", s.controllers.integration.MapUser)
+		r.Get("/users", s.controllers.integration.ListUserMappings)
+	})
+}
+
+// actorMiddleware attributes the request to the caller named in the X-Actor header so audit records
+// written further down carry it.
+func actorMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if actor := r.Header.Get(audit.ActorHeader); actor != "" {
+			r = r.WithContext(audit.WithActor(r.Context(), actor))
+		}
+		next.ServeHTTP(w, r)
+	})
 }
 
 func (s *HTTPServer) requestLoggingMiddleware(next http.Handler) http.Handler {
@@ -192,44 +294,21 @@ func (s *HTTPServer) requestLoggingMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-func initializeRepositories(db *gorm.DB) *repositoriesRegistry {
-	return &repositoriesRegistry{
-		user:        repository.NewUserRepository(db),
-		team:        repository.NewTeamRepository(db),
-		pullRequest: repository.NewPullRequestRepository(db),
-		statistics:  repository.NewStatisticsRepository(db),
-	}
-}
-
-type repositoriesRegistry struct {
-	user        *repository.UserRepository
-	team        *repository.TeamRepository
-	pullRequest *repository.PullRequestRepository
-	statistics  *repository.StatisticsRepository
-}
-
-func initializeServices(repos *repositoriesRegistry, logger *slog.Logger) *servicesRegistry {
-	return &servicesRegistry{
-		user:        services.NewUserService(repos.user),
-		team:        services.NewTeamService(repos.team, repos.pullRequest, logger),
-		pullRequest: services.NewPullRequestService(repos.pullRequest, repos.user),
-		statistics:  services.NewStatisticsService(repos.statistics),
-	}
-}
-
-type servicesRegistry struct {
-	user        *services.UserService
-	team        *services.TeamService
-	pullRequest *services.PullRequestService
-	statistics  *services.StatisticsService
-}
-
-func initializeControllers(svcs *servicesRegistry, logger *slog.Logger) *controllersRegistry {
+func initializeControllers(svcs Services, logger *slog.Logger) *controllersRegistry {
 	return &controllersRegistry{
-		user:        NewUserController(svcs.user, logger),
-		team:        NewTeamController(svcs.team, logger),
-		pullRequest: NewPullRequestController(svcs.pullRequest, logger),
-		statistics:  NewStatisticsController(svcs.statistics, logger),
+		user:         NewUserController(svcs.User, logger),
+		team:         NewTeamController(svcs.Team, logger),
+		pullRequest:  NewPullRequestController(svcs.PullRequest, logger),
+		statistics:   NewStatisticsController(svcs.Statistics, logger),
+		sla:          NewSLAController(svcs.SLA, logger),
+		calendar:     NewCalendarController(svcs.Calendar, logger),
+		notification: NewNotificationController(svcs.Notification, logger),
+		webhook:      NewWebhookController(svcs.Webhook, logger),
+		integration:  NewIntegrationController(svcs.Integration, logger),
+		manifest:     NewManifestController(svcs.Manifest, logger),
+		audit:        NewAuditController(svcs.Audit, logger),
+		membership:   NewMembershipController(svcs.Membership, logger),
+		scheduledJob: NewScheduledJobController(svcs.ScheduledJob, logger),
 	}
 }
 
@@ -0,0 +1,156 @@
+package controllers
+
+import (
+	"CodeRewievService/internal/integrations"
+	"CodeRewievService/internal/models"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+)
+
+const maxIntegrationPayloadSize = 5 << 20
+
+type IntegrationController struct {
+	service IntegrationService
+	logger  *slog.Logger
+}
+
+func NewIntegrationController(service IntegrationService, logger *slog.Logger) *IntegrationController {
+	return &IntegrationController{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (ctrl *IntegrationController) HandleGitHubWebhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIntegrationPayloadSize))
+	if err != nil {
+		ctrl.sendErrorResponse(w, "Invalid request body", http.StatusBadRequest)
+		ctrl.logger.Error("Failed to read webhook body", "error", err)
+		return
+	}
+
+	event := r.Header.Get(integrations.GitHubEventHeader)
+	deliveryID := r.Header.Get(integrations.GitHubDeliveryHeader)
+
+	result, err := ctrl.service.HandleGitHubEvent(
+		r.Context(),
+		event,
+		deliveryID,
+		r.Header.Get(integrations.GitHubSignatureHeader),
+		body,
+	)
+	if err != nil {
+		ctrl.handleIntegrationError(w, err, "deliveryID", deliveryID, "event", event)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, result, http.StatusOK)
+}
+
+func (ctrl *IntegrationController) HandleGitLabWebhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIntegrationPayloadSize))
+	if err != nil {
+		ctrl.sendErrorResponse(w, "Invalid request body", http.StatusBadRequest)
+		ctrl.logger.Error("Failed to read webhook body", "error", err)
+		return
+	}
+
+	event := r.Header.Get(integrations.GitLabEventHeader)
+	deliveryID := integrations.GitLabDeliveryID(
+		r.Header.Get(integrations.GitLabIdempotencyKeyHeader),
+		r.Header.Get(integrations.GitLabEventUUIDHeader),
+		body,
+	)
+
+	result, err := ctrl.service.HandleGitLabEvent(r.Context(), event, r.Header.Get(integrations.GitLabTokenHeader), deliveryID, body)
+	if err != nil {
+		ctrl.handleIntegrationError(w, err, "deliveryID", deliveryID, "event", event)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, result, http.StatusOK)
+}
+
+func (ctrl *IntegrationController) MapUser(w http.ResponseWriter, r *http.Request) {
+	var req models.RequestMapExternalUser
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ctrl.sendErrorResponse(w, "Invalid request body", http.StatusBadRequest)
+		ctrl.logger.Error("Failed to decode request body", "error", err)
+		return
+	}
+
+	mapping, err := ctrl.service.MapUser(&req)
+	if errors.Is(err, models.ErrUserNotFound) {
+		ctrl.sendNotFoundResponse(w)
+		return
+	}
+
+	if errors.Is(err, models.ErrInvalidUserMapping) {
+		ctrl.sendErrorResponse(w, "provider must be known, externalLogin and userId are required", http.StatusBadRequest)
+		return
+	}
+
+	if err != nil {
+		ctrl.logger.Error("Failed to map external user", "error", err, "provider", req.Provider, "login", req.ExternalLogin)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, mapping, http.StatusOK)
+}
+
+func (ctrl *IntegrationController) ListUserMappings(w http.ResponseWriter, r *http.Request) {
+	mappings, err := ctrl.service.ListUserMappings(r.URL.Query().Get("provider"))
+	if err != nil {
+		ctrl.logger.Error("Failed to list external user mappings", "error", err)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, models.ResponseExternalUserMappings{Mappings: mappings}, http.StatusOK)
+}
+
+func (ctrl *IntegrationController) handleIntegrationError(w http.ResponseWriter, err error, logArgs ...any) {
+	switch {
+	case errors.Is(err, models.ErrIntegrationDisabled):
+		ctrl.sendErrorResponse(w, err.Error(), http.StatusServiceUnavailable)
+	case errors.Is(err, models.ErrInvalidSignature):
+		ctrl.sendErrorResponse(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, models.ErrInvalidIntegrationEvent):
+		ctrl.sendErrorResponse(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, models.ErrExternalUserNotMapped),
+		errors.Is(err, models.ErrAuthorNotFoundOrInactive),
+		errors.Is(err, models.ErrPullRequestClosed):
+		ctrl.logger.Warn("Integration event rejected", append(logArgs, "error", err)...)
+		ctrl.sendErrorResponse(w, err.Error(), http.StatusUnprocessableEntity)
+	default:
+		ctrl.logger.Error("Failed to handle integration event", append(logArgs, "error", err)...)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (ctrl *IntegrationController) sendJSONResponse(w http.ResponseWriter, data interface{}, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		ctrl.logger.Error("Failed to encode JSON response", "error", err)
+	}
+}
+
+func (ctrl *IntegrationController) sendErrorResponse(w http.ResponseWriter, message string, statusCode int) {
+	ctrl.sendJSONResponse(w, models.Error{
+		Code:    "ERROR",
+		Message: message,
+	}, statusCode)
+}
+
+func (ctrl *IntegrationController) sendNotFoundResponse(w http.ResponseWriter) {
+	ctrl.sendJSONResponse(w, models.Error{
+		Code:    "NOT_FOUND",
+		Message: "resource not found",
+	}, http.StatusNotFound)
+}
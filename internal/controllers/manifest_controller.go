@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"CodeRewievService/internal/manifest"
+	"CodeRewievService/internal/models"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+const maxManifestFileSize = 5 << 20
+
+type ManifestController struct {
+	service ManifestService
+	logger  *slog.Logger
+}
+
+func NewManifestController(service ManifestService, logger *slog.Logger) *ManifestController {
+	return &ManifestController{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (ctrl *ManifestController) ApplyManifest(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = manifestFormatFromContentType(r.Header.Get("Content-Type"))
+	}
+
+	dryRun := r.URL.Query().Get("dryRun") == "true"
+	body := http.MaxBytesReader(w, r.Body, maxManifestFileSize)
+
+	diff, err := ctrl.service.ApplyManifest(r.Context(), format, body, dryRun)
+	if errors.Is(err, models.ErrInvalidManifest) {
+		ctrl.sendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err != nil {
+		ctrl.logger.Error("Failed to apply teams manifest", "error", err, "dryRun", dryRun)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, diff, http.StatusOK)
+}
+
+func manifestFormatFromContentType(contentType string) string {
+	if strings.HasPrefix(contentType, "text/csv") {
+		return manifest.FormatCSV
+	}
+	return manifest.FormatYAML
+}
+
+func (ctrl *ManifestController) sendJSONResponse(w http.ResponseWriter, data interface{}, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		ctrl.logger.Error("Failed to encode JSON response", "error", err)
+	}
+}
+
+func (ctrl *ManifestController) sendErrorResponse(w http.ResponseWriter, message string, statusCode int) {
+	ctrl.sendJSONResponse(w, models.Error{
+		Code:    "ERROR",
+		Message: message,
+	}, statusCode)
+}
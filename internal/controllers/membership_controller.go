@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"CodeRewievService/internal/models"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+type MembershipController struct {
+	service MembershipService
+	logger  *slog.Logger
+}
+
+func NewMembershipController(service MembershipService, logger *slog.Logger) *MembershipController {
+	return &MembershipController{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (ctrl *MembershipController) AddMember(w http.ResponseWriter, r *http.Request) {
+	var req models.RequestAddTeamMember
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ctrl.sendErrorResponse(w, "Invalid request body", http.StatusBadRequest)
+		ctrl.logger.Error("Failed to decode request body", "error", err)
+		return
+	}
+
+	change, err := ctrl.service.AddMember(r.Context(), &req)
+	if err != nil {
+		ctrl.handleMembershipError(w, err, "teamName", req.TeamName, "userID", req.UserID)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, change, http.StatusCreated)
+}
+
+func (ctrl *MembershipController) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	var req models.RequestRemoveTeamMember
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ctrl.sendErrorResponse(w, "Invalid request body", http.StatusBadRequest)
+		ctrl.logger.Error("Failed to decode request body", "error", err)
+		return
+	}
+
+	change, err := ctrl.service.RemoveMember(r.Context(), &req)
+	if err != nil {
+		ctrl.handleMembershipError(w, err, "teamName", req.TeamName, "userID", req.UserID)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, change, http.StatusOK)
+}
+
+func (ctrl *MembershipController) MoveMember(w http.ResponseWriter, r *http.Request) {
+	var req models.RequestMoveTeamMember
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ctrl.sendErrorResponse(w, "Invalid request body", http.StatusBadRequest)
+		ctrl.logger.Error("Failed to decode request body", "error", err)
+		return
+	}
+
+	change, err := ctrl.service.MoveMember(r.Context(), &req)
+	if err != nil {
+		ctrl.handleMembershipError(w, err, "toTeam", req.ToTeam, "userID", req.UserID)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, change, http.StatusOK)
+}
+
+func (ctrl *MembershipController) handleMembershipError(w http.ResponseWriter, err error, logArgs ...any) {
+	switch {
+	case errors.Is(err, models.ErrInvalidMembershipChange):
+		ctrl.sendErrorResponse(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, models.ErrTeamNotFound), errors.Is(err, models.ErrUserNotFound):
+		ctrl.sendNotFoundResponse(w)
+	case errors.Is(err, models.ErrNotTeamMember):
+		ctrl.sendConflictResponse(w, "NOT_MEMBER", err.Error())
+	case errors.Is(err, models.ErrAlreadyTeamMember):
+		ctrl.sendConflictResponse(w, "ALREADY_MEMBER", err.Error())
+	case errors.Is(err, models.ErrTeamArchived):
+		ctrl.sendConflictResponse(w, "TEAM_ARCHIVED", err.Error())
+	default:
+		ctrl.logger.Error("Failed to change team membership", append(logArgs, "error", err)...)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (ctrl *MembershipController) sendJSONResponse(w http.ResponseWriter, data interface{}, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		ctrl.logger.Error("Failed to encode JSON response", "error", err)
+	}
+}
+
+func (ctrl *MembershipController) sendErrorResponse(w http.ResponseWriter, message string, statusCode int) {
+	ctrl.sendJSONResponse(w, models.Error{
+		Code:    "ERROR",
+		Message: message,
+	}, statusCode)
+}
+
+func (ctrl *MembershipController) sendNotFoundResponse(w http.ResponseWriter) {
+	ctrl.sendJSONResponse(w, models.Error{
+		Code:    "NOT_FOUND",
+		Message: "resource not found",
+	}, http.StatusNotFound)
+}
+
+func (ctrl *MembershipController) sendConflictResponse(w http.ResponseWriter, code, message string) {
+	ctrl.sendJSONResponse(w, models.Error{
+		Code:    code,
+		Message: message,
+	}, http.StatusConflict)
+}
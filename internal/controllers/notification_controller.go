@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"CodeRewievService/internal/models"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+type NotificationController struct {
+	service NotificationService
+	logger  *slog.Logger
+}
+
+func NewNotificationController(service NotificationService, logger *slog.Logger) *NotificationController {
+	return &NotificationController{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (ctrl *NotificationController) SetPreferences(w http.ResponseWriter, r *http.Request) {
+	var req models.RequestSetNotificationPreferences
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ctrl.sendErrorResponse(w, "Invalid request body", http.StatusBadRequest)
+		ctrl.logger.Error("Failed to decode request body", "error", err)
+		return
+	}
+
+	preferences, err := ctrl.service.SetPreferences(&req)
+	if errors.Is(err, models.ErrUserNotFound) {
+		ctrl.sendNotFoundResponse(w)
+		return
+	}
+
+	if errors.Is(err, models.ErrInvalidNotificationPrefs) {
+		ctrl.sendErrorResponse(w, "each channel must be one of WEBHOOK, SLACK, EMAIL, appear once and have an address",
+			http.StatusBadRequest)
+		return
+	}
+
+	if err != nil {
+		ctrl.logger.Error("Failed to set notification preferences", "error", err, "userID", req.UserID)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, preferences, http.StatusOK)
+}
+
+func (ctrl *NotificationController) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		ctrl.sendErrorResponse(w, "user_id parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	preferences, err := ctrl.service.GetPreferences(userID)
+	if errors.Is(err, models.ErrUserNotFound) {
+		ctrl.sendNotFoundResponse(w)
+		return
+	}
+
+	if err != nil {
+		ctrl.logger.Error("Failed to get notification preferences", "error", err, "userID", userID)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, preferences, http.StatusOK)
+}
+
+func (ctrl *NotificationController) sendJSONResponse(w http.ResponseWriter, data interface{}, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		ctrl.logger.Error("Failed to encode JSON response", "error", err)
+	}
+}
+
+func (ctrl *NotificationController) sendErrorResponse(w http.ResponseWriter, message string, statusCode int) {
+	ctrl.sendJSONResponse(w, models.Error{
+		Code:    "ERROR",
+		Message: message,
+	}, statusCode)
+}
+
+func (ctrl *NotificationController) sendNotFoundResponse(w http.ResponseWriter) {
+	ctrl.sendJSONResponse(w, models.Error{
+		Code:    "NOT_FOUND",
+		Message: "resource not found",
+	}, http.StatusNotFound)
+}
@@ -29,10 +29,11 @@ func (ctrl *PullRequestController) CreatePR(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	pr, err := ctrl.service.Create(&models.PullRequest{
+	pr, err := ctrl.service.Create(r.Context(), &models.PullRequest{
 		PullRequestID:   req.PullRequestID,
 		PullRequestName: req.PullRequestName,
 		AuthorID:        req.AuthorID,
+		TeamName:        req.TeamName,
 		Status:          "OPEN",
 	})
 
@@ -42,8 +43,13 @@ func (ctrl *PullRequestController) CreatePR(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	if errors.Is(err, models.ErrAuthorNotFoundOrInactive) {
-		ctrl.logger.Error("Author or team not found", "authorID", req.AuthorID)
+	if errors.Is(err, models.ErrTeamArchived) {
+		ctrl.sendConflictResponse(w, "TEAM_ARCHIVED", "repository team is archived")
+		return
+	}
+
+	if errors.Is(err, models.ErrAuthorNotFoundOrInactive) || errors.Is(err, models.ErrTeamNotFound) {
+		ctrl.logger.Error("Author or team not found", "authorID", req.AuthorID, "teamName", req.TeamName)
 		ctrl.sendNotFoundResponse(w)
 		return
 	}
@@ -67,7 +73,7 @@ func (ctrl *PullRequestController) MergePR(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	pr, err := ctrl.service.Merge(req.PullRequestID)
+	pr, err := ctrl.service.Merge(r.Context(), req.PullRequestID)
 
 	if errors.Is(err, models.ErrPullRequestNotFound) {
 		ctrl.logger.Error("PR not found for merge", "prID", req.PullRequestID)
@@ -75,6 +81,12 @@ func (ctrl *PullRequestController) MergePR(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	if errors.Is(err, models.ErrPullRequestClosed) {
+		ctrl.logger.Error("Cannot merge closed PR", "prID", req.PullRequestID)
+		ctrl.sendConflictResponse(w, "PR_CLOSED", "cannot merge closed PR")
+		return
+	}
+
 	if err != nil {
 		ctrl.logger.Error("Failed to merge PR", "error", err, "prID", req.PullRequestID)
 		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
@@ -95,7 +107,7 @@ func (ctrl *PullRequestController) ReassignPR(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	pr, newUserID, err := ctrl.service.Reassign(req.PullRequestID, req.OldReviewerID)
+	pr, newUserID, err := ctrl.service.Reassign(r.Context(), req.PullRequestID, req.OldReviewerID, models.ReviewerChangeManual)
 
 	if errors.Is(err, models.ErrPullRequestAlreadyMerged) {
 		ctrl.logger.Error("Cannot reassign merged PR", "prID", req.PullRequestID)
@@ -103,6 +115,12 @@ func (ctrl *PullRequestController) ReassignPR(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if errors.Is(err, models.ErrPullRequestClosed) {
+		ctrl.logger.Error("Cannot reassign closed PR", "prID", req.PullRequestID)
+		ctrl.sendConflictResponse(w, "PR_CLOSED", "cannot reassign on closed PR")
+		return
+	}
+
 	if errors.Is(err, models.ErrReviewerNotAssigned) {
 		ctrl.logger.Error("Reviewer not assigned to PR", "prID", req.PullRequestID, "reviewerID", req.OldReviewerID)
 		ctrl.sendConflictResponse(w, "NOT_ASSIGNED", "reviewer not assigned to this PR")
@@ -133,6 +151,72 @@ func (ctrl *PullRequestController) ReassignPR(w http.ResponseWriter, r *http.Req
 	}, http.StatusOK)
 }
 
+func (ctrl *PullRequestController) SubmitReview(w http.ResponseWriter, r *http.Request) {
+	var req models.RequestSubmitReview
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ctrl.sendErrorResponse(w, "Invalid request body", http.StatusBadRequest)
+		ctrl.logger.Error("Failed to decode request body", "error", err)
+		return
+	}
+
+	verdict, err := ctrl.service.SubmitReview(r.Context(), &req)
+
+	if errors.Is(err, models.ErrInvalidReviewVerdict) {
+		ctrl.sendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if errors.Is(err, models.ErrPullRequestNotFound) {
+		ctrl.sendNotFoundResponse(w)
+		return
+	}
+
+	if errors.Is(err, models.ErrPullRequestAlreadyMerged) {
+		ctrl.sendConflictResponse(w, "PR_MERGED", "cannot review merged PR")
+		return
+	}
+
+	if errors.Is(err, models.ErrPullRequestClosed) {
+		ctrl.sendConflictResponse(w, "PR_CLOSED", "cannot review closed PR")
+		return
+	}
+
+	if errors.Is(err, models.ErrReviewerNotAssigned) {
+		ctrl.sendConflictResponse(w, "NOT_ASSIGNED", "reviewer not assigned to this PR")
+		return
+	}
+
+	if err != nil {
+		ctrl.logger.Error("Failed to submit review", "error", err, "prID", req.PullRequestID)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, models.ResponseSubmitReview{Review: *verdict}, http.StatusCreated)
+}
+
+func (ctrl *PullRequestController) GetHistory(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		ctrl.sendErrorResponse(w, "pull_request_id parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	history, err := ctrl.service.History(prID)
+	if errors.Is(err, models.ErrPullRequestNotFound) {
+		ctrl.sendNotFoundResponse(w)
+		return
+	}
+
+	if err != nil {
+		ctrl.logger.Error("Failed to get PR history", "error", err, "prID", prID)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, history, http.StatusOK)
+}
+
 func (ctrl *PullRequestController) sendJSONResponse(w http.ResponseWriter, data interface{}, statusCode int) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
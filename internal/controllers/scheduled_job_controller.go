@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"CodeRewievService/internal/models"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type ScheduledJobController struct {
+	service ScheduledJobService
+	logger  *slog.Logger
+}
+
+func NewScheduledJobController(service ScheduledJobService, logger *slog.Logger) *ScheduledJobController {
+	return &ScheduledJobController{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (ctrl *ScheduledJobController) Schedule(w http.ResponseWriter, r *http.Request) {
+	var req models.RequestScheduleJob
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ctrl.sendErrorResponse(w, "Invalid request body", http.StatusBadRequest)
+		ctrl.logger.Error("Failed to decode request body", "error", err)
+		return
+	}
+
+	job, err := ctrl.service.Schedule(r.Context(), &req)
+	switch {
+	case errors.Is(err, models.ErrInvalidScheduledJob):
+		ctrl.sendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	case errors.Is(err, models.ErrUserNotFound), errors.Is(err, models.ErrTeamNotFound):
+		ctrl.sendNotFoundResponse(w)
+		return
+	case err != nil:
+		ctrl.logger.Error("Failed to schedule job", "error", err, "type", req.Type)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, job, http.StatusCreated)
+}
+
+func (ctrl *ScheduledJobController) Cancel(w http.ResponseWriter, r *http.Request) {
+	var req models.RequestCancelScheduledJob
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ctrl.sendErrorResponse(w, "Invalid request body", http.StatusBadRequest)
+		ctrl.logger.Error("Failed to decode request body", "error", err)
+		return
+	}
+
+	job, err := ctrl.service.Cancel(r.Context(), req.JobID)
+	switch {
+	case errors.Is(err, models.ErrScheduledJobNotFound):
+		ctrl.sendNotFoundResponse(w)
+		return
+	case errors.Is(err, models.ErrScheduledJobNotPending):
+		ctrl.sendErrorResponse(w, err.Error(), http.StatusConflict)
+		return
+	case err != nil:
+		ctrl.logger.Error("Failed to cancel scheduled job", "error", err, "jobID", req.JobID)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, job, http.StatusOK)
+}
+
+func (ctrl *ScheduledJobController) Get(w http.ResponseWriter, r *http.Request) {
+	jobID, err := strconv.ParseInt(r.URL.Query().Get("job_id"), 10, 64)
+	if err != nil {
+		ctrl.sendErrorResponse(w, "job_id must be an integer", http.StatusBadRequest)
+		return
+	}
+
+	job, err := ctrl.service.Get(jobID)
+	if errors.Is(err, models.ErrScheduledJobNotFound) {
+		ctrl.sendNotFoundResponse(w)
+		return
+	}
+
+	if err != nil {
+		ctrl.logger.Error("Failed to get scheduled job", "error", err, "jobID", jobID)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, job, http.StatusOK)
+}
+
+func (ctrl *ScheduledJobController) List(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := models.ScheduledJobFilter{
+		Status:  query.Get("status"),
+		JobType: query.Get("type"),
+	}
+
+	if value := query.Get("limit"); value != "" {
+		var err error
+		if filter.Limit, err = strconv.Atoi(value); err != nil {
+			ctrl.sendErrorResponse(w, "limit must be an integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	jobs, err := ctrl.service.List(filter)
+	if err != nil {
+		ctrl.logger.Error("Failed to list scheduled jobs", "error", err)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, models.ResponseScheduledJobs{Jobs: jobs}, http.StatusOK)
+}
+
+func (ctrl *ScheduledJobController) sendJSONResponse(w http.ResponseWriter, data interface{}, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		ctrl.logger.Error("Failed to encode JSON response", "error", err)
+	}
+}
+
+func (ctrl *ScheduledJobController) sendErrorResponse(w http.ResponseWriter, message string, statusCode int) {
+	ctrl.sendJSONResponse(w, models.Error{
+		Code:    "ERROR",
+		Message: message,
+	}, statusCode)
+}
+
+func (ctrl *ScheduledJobController) sendNotFoundResponse(w http.ResponseWriter) {
+	ctrl.sendJSONResponse(w, models.Error{
+		Code:    "NOT_FOUND",
+		Message: "resource not found",
+	}, http.StatusNotFound)
+}
@@ -2,26 +2,112 @@ package controllers
 
 import (
 	"CodeRewievService/internal/models"
+	"context"
+	"io"
 )
 
+type Services struct {
+	User         UserService
+	Team         TeamService
+	PullRequest  PullRequestService
+	Statistics   StatisticsService
+	SLA          SLAService
+	Calendar     CalendarService
+	Notification NotificationService
+	Webhook      WebhookService
+	Integration  IntegrationService
+	Manifest     ManifestService
+	Audit        AuditService
+	Membership   MembershipService
+	ScheduledJob ScheduledJobService
+}
+
+type ScheduledJobService interface {
+	Schedule(ctx context.Context, req *models.RequestScheduleJob) (*models.ScheduledJob, error)
+	Cancel(ctx context.Context, jobID int64) (*models.ScheduledJob, error)
+	Get(jobID int64) (*models.ScheduledJob, error)
+	List(filter models.ScheduledJobFilter) ([]models.ScheduledJob, error)
+}
+
 type StatisticsService interface {
-	GetAssignmentsStats(teamName string) ([]models.AssignmentStats, error)
+	GetAssignmentsStats(filter models.StatisticsFilter) ([]models.AssignmentStats, error)
+	GetFairness(teamName string, includeSubTeams bool) (*models.TeamFairness, error)
+	GetTurnaround(filter models.StatisticsFilter) (*models.TeamTurnaround, error)
+	GetOverview(filter models.OverviewFilter) (*models.OrgOverview, error)
 	TeamExists(teamName string) (bool, error)
 }
 
 type PullRequestService interface {
-	Create(PullRequest *models.PullRequest) (*models.PullRequest, error)
-	Reassign(prID string, userID string) (*models.PullRequest, string, error)
-	Merge(prID string) (*models.PullRequest, error)
+	Create(ctx context.Context, PullRequest *models.PullRequest) (*models.PullRequest, error)
+	Reassign(ctx context.Context, prID, userID, reason string) (*models.PullRequest, string, error)
+	Merge(ctx context.Context, prID string) (*models.PullRequest, error)
+	SubmitReview(ctx context.Context, req *models.RequestSubmitReview) (*models.ReviewVerdict, error)
+	History(prID string) (*models.ResponsePullRequestHistory, error)
 }
 
 type TeamService interface {
-	Add(team *models.Team) error
-	Get(teamName string) (*models.Team, error)
-	MassDeactivateTeamUsers(teamName string) error
+	Add(ctx context.Context, team *models.Team) error
+	Get(teamName string, includeSubTeams bool) (*models.Team, error)
+	SetParent(ctx context.Context, req *models.RequestSetParentTeam) (*models.Team, error)
+	List(includeArchived bool) ([]models.TeamSummary, error)
+	Archive(ctx context.Context, req *models.RequestArchiveTeam) (*models.TeamArchiveResult, error)
+	Delete(ctx context.Context, teamName string) error
+	Rename(ctx context.Context, req *models.RequestRenameTeam) (*models.Team, error)
+	MassDeactivateTeamUsers(ctx context.Context, teamName string, dryRun bool) (*models.TeamDeactivationResult, error)
+	Reactivate(ctx context.Context, deactivationID int64) (*models.TeamReactivationResult, error)
 }
 
 type UserService interface {
-	SetIsActive(user *models.User) (*models.User, error)
+	SetIsActive(ctx context.Context, user *models.User) (*models.User, error)
+	BulkSetIsActive(ctx context.Context, req *models.RequestBulkSetIsActive) (*models.ResponseBulkSetIsActive, error)
+	Get(userID string) (*models.ResponseUser, error)
+	List(filter models.UserFilter) (*models.ResponseUserList, error)
+	Update(ctx context.Context, req *models.RequestUpdateUser) (*models.User, error)
 	GetReview(userID string) (*models.UserReview, error)
 }
+
+type SLAService interface {
+	SetTeamSLA(sla *models.ReviewSLA) (*models.ReviewSLA, error)
+	GetTeamSLA(teamName string) (*models.ReviewSLA, error)
+	GetOverdueReviews(teamName string) ([]models.OverdueReview, error)
+}
+
+type CalendarService interface {
+	SetTeamCalendar(req *models.RequestSetTeamCalendar) (*models.TeamCalendarDTO, error)
+	GetTeamCalendar(teamName string) (*models.TeamCalendarDTO, error)
+	ImportHolidays(teamName, format string, reader io.Reader, replace bool) (int, error)
+}
+
+type NotificationService interface {
+	GetPreferences(userID string) (*models.UserNotificationPreferences, error)
+	SetPreferences(req *models.RequestSetNotificationPreferences) (*models.UserNotificationPreferences, error)
+}
+
+type WebhookService interface {
+	Subscribe(req *models.RequestCreateWebhook) (*models.WebhookSubscriptionDTO, error)
+	ListSubscriptions() ([]models.WebhookSubscriptionDTO, error)
+	Unsubscribe(subscriptionID int64) error
+	ListDeliveries(filter models.WebhookDeliveryFilter) ([]models.WebhookDelivery, error)
+	Redeliver(deliveryID int64, force bool) error
+}
+
+type IntegrationService interface {
+	HandleGitHubEvent(ctx context.Context, event, deliveryID, signature string, body []byte) (*models.IntegrationResult, error)
+	HandleGitLabEvent(ctx context.Context, event, token, deliveryID string, body []byte) (*models.IntegrationResult, error)
+	MapUser(req *models.RequestMapExternalUser) (*models.ExternalUserMapping, error)
+	ListUserMappings(provider string) ([]models.ExternalUserMapping, error)
+}
+
+type ManifestService interface {
+	ApplyManifest(ctx context.Context, format string, reader io.Reader, dryRun bool) (*models.ManifestDiff, error)
+}
+
+type MembershipService interface {
+	AddMember(ctx context.Context, req *models.RequestAddTeamMember) (*models.MembershipChange, error)
+	RemoveMember(ctx context.Context, req *models.RequestRemoveTeamMember) (*models.MembershipChange, error)
+	MoveMember(ctx context.Context, req *models.RequestMoveTeamMember) (*models.MembershipChange, error)
+}
+
+type AuditService interface {
+	List(filter models.AuditFilter) ([]models.AuditRecord, error)
+}
@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"CodeRewievService/internal/models"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+type SLAController struct {
+	service SLAService
+	logger  *slog.Logger
+}
+
+func NewSLAController(service SLAService, logger *slog.Logger) *SLAController {
+	return &SLAController{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (ctrl *SLAController) SetTeamSLA(w http.ResponseWriter, r *http.Request) {
+	var req models.RequestSetReviewSLA
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ctrl.sendErrorResponse(w, "Invalid request body", http.StatusBadRequest)
+		ctrl.logger.Error("Failed to decode request body", "error", err)
+		return
+	}
+
+	sla, err := ctrl.service.SetTeamSLA(&models.ReviewSLA{
+		TeamName:           req.TeamName,
+		FirstReviewMinutes: req.FirstReviewMinutes,
+		Action:             req.Action,
+	})
+
+	if errors.Is(err, models.ErrTeamNotFound) {
+		ctrl.logger.Error("Team not found for SLA", "teamName", req.TeamName)
+		ctrl.sendNotFoundResponse(w)
+		return
+	}
+
+	if errors.Is(err, models.ErrInvalidSLA) {
+		ctrl.sendErrorResponse(w, "firstReviewMinutes must be positive and action one of NOTIFY, ADD_REVIEWER, REASSIGN",
+			http.StatusBadRequest)
+		return
+	}
+
+	if err != nil {
+		ctrl.logger.Error("Failed to set team SLA", "error", err, "teamName", req.TeamName)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, sla, http.StatusOK)
+}
+
+func (ctrl *SLAController) GetTeamSLA(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		ctrl.sendErrorResponse(w, "team_name parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	sla, err := ctrl.service.GetTeamSLA(teamName)
+	if errors.Is(err, models.ErrSLANotFound) {
+		ctrl.sendNotFoundResponse(w)
+		return
+	}
+
+	if err != nil {
+		ctrl.logger.Error("Failed to get team SLA", "error", err, "teamName", teamName)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, sla, http.StatusOK)
+}
+
+func (ctrl *SLAController) GetOverdueReviews(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+
+	overdue, err := ctrl.service.GetOverdueReviews(teamName)
+	if errors.Is(err, models.ErrTeamNotFound) {
+		ctrl.sendNotFoundResponse(w)
+		return
+	}
+
+	if err != nil {
+		ctrl.logger.Error("Failed to get overdue reviews", "error", err, "teamName", teamName)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, models.ResponseOverdueReviews{OverdueReviews: overdue}, http.StatusOK)
+}
+
+func (ctrl *SLAController) sendJSONResponse(w http.ResponseWriter, data interface{}, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		ctrl.logger.Error("Failed to encode JSON response", "error", err)
+	}
+}
+
+func (ctrl *SLAController) sendErrorResponse(w http.ResponseWriter, message string, statusCode int) {
+	ctrl.sendJSONResponse(w, models.Error{
+		Code:    "ERROR",
+		Message: message,
+	}, statusCode)
+}
+
+func (ctrl *SLAController) sendNotFoundResponse(w http.ResponseWriter) {
+	ctrl.sendJSONResponse(w, models.Error{
+		Code:    "NOT_FOUND",
+		Message: "resource not found",
+	}, http.StatusNotFound)
+}
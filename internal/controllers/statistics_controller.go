@@ -3,8 +3,11 @@ package controllers
 import (
 	"CodeRewievService/internal/models"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 type StatisticsController struct {
@@ -20,6 +23,120 @@ func NewStatisticsController(service StatisticsService, logger *slog.Logger) *St
 }
 
 func (ctrl *StatisticsController) GetAssignmentsStats(w http.ResponseWriter, r *http.Request) {
+	filter, ok := ctrl.readFilter(w, r)
+	if !ok {
+		return
+	}
+	filter.GroupBy = r.URL.Query().Get("groupBy")
+
+	stats, err := ctrl.service.GetAssignmentsStats(filter)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidStatisticsRequest) {
+			ctrl.sendErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ctrl.logger.Error("Failed to get assignments stats", "error", err, "team", filter.TeamName)
+		ctrl.sendErrorResponse(w, "failed to get assignments statistics", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, stats, http.StatusOK)
+}
+
+func (ctrl *StatisticsController) GetTurnaround(w http.ResponseWriter, r *http.Request) {
+	filter, ok := ctrl.readFilter(w, r)
+	if !ok {
+		return
+	}
+
+	turnaround, err := ctrl.service.GetTurnaround(filter)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidStatisticsRequest) {
+			ctrl.sendErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ctrl.logger.Error("Failed to get review turnaround", "error", err, "team", filter.TeamName)
+		ctrl.sendErrorResponse(w, "failed to get review turnaround", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, turnaround, http.StatusOK)
+}
+
+func (ctrl *StatisticsController) GetOverview(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var filter models.OverviewFilter
+	var err error
+	if filter.From, err = parseStatisticsTime(query.Get("from")); err != nil {
+		ctrl.sendErrorResponse(w, "from must be an RFC 3339 timestamp", http.StatusBadRequest)
+		return
+	}
+
+	if filter.To, err = parseStatisticsTime(query.Get("to")); err != nil {
+		ctrl.sendErrorResponse(w, "to must be an RFC 3339 timestamp", http.StatusBadRequest)
+		return
+	}
+
+	if value := query.Get("limit"); value != "" {
+		if filter.Limit, err = strconv.Atoi(value); err != nil {
+			ctrl.sendErrorResponse(w, "limit must be an integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	overview, err := ctrl.service.GetOverview(filter)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidStatisticsRequest) {
+			ctrl.sendErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ctrl.logger.Error("Failed to get statistics overview", "error", err)
+		ctrl.sendErrorResponse(w, "failed to get statistics overview", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, overview, http.StatusOK)
+}
+
+// readFilter reads team_name, include_subteams, from and to, answering the request itself when they are invalid.
+func (ctrl *StatisticsController) readFilter(w http.ResponseWriter, r *http.Request) (models.StatisticsFilter, bool) {
+	query := r.URL.Query()
+	filter := models.StatisticsFilter{
+		TeamName:        query.Get("team_name"),
+		IncludeSubTeams: query.Get("include_subteams") == "true",
+	}
+
+	if filter.TeamName == "" {
+		ctrl.sendErrorResponse(w, "team_name is required", http.StatusBadRequest)
+		return filter, false
+	}
+
+	if err := ctrl.validateTeamExists(filter.TeamName); err != nil {
+		ctrl.logger.Error("Team validation failed", "error", err, "team", filter.TeamName)
+		if err == errTeamNotFound {
+			ctrl.sendErrorResponse(w, "team not found", http.StatusNotFound)
+		} else {
+			ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		}
+		return filter, false
+	}
+
+	var err error
+	if filter.From, err = parseStatisticsTime(query.Get("from")); err != nil {
+		ctrl.sendErrorResponse(w, "from must be an RFC 3339 timestamp", http.StatusBadRequest)
+		return filter, false
+	}
+
+	if filter.To, err = parseStatisticsTime(query.Get("to")); err != nil {
+		ctrl.sendErrorResponse(w, "to must be an RFC 3339 timestamp", http.StatusBadRequest)
+		return filter, false
+	}
+
+	return filter, true
+}
+
+func (ctrl *StatisticsController) GetFairness(w http.ResponseWriter, r *http.Request) {
 	teamName := r.URL.Query().Get("team_name")
 	if teamName == "" {
 		ctrl.sendErrorResponse(w, "team_name is required", http.StatusBadRequest)
@@ -36,14 +153,26 @@ func (ctrl *StatisticsController) GetAssignmentsStats(w http.ResponseWriter, r *
 		return
 	}
 
-	stats, err := ctrl.service.GetAssignmentsStats(teamName)
+	fairness, err := ctrl.service.GetFairness(teamName, r.URL.Query().Get("include_subteams") == "true")
 	if err != nil {
-		ctrl.logger.Error("Failed to get assignments stats", "error", err, "team", teamName)
-		ctrl.sendErrorResponse(w, "failed to get assignments statistics", http.StatusInternalServerError)
+		ctrl.logger.Error("Failed to get review load fairness", "error", err, "team", teamName)
+		ctrl.sendErrorResponse(w, "failed to get review load fairness", http.StatusInternalServerError)
 		return
 	}
 
-	ctrl.sendJSONResponse(w, stats, http.StatusOK)
+	ctrl.sendJSONResponse(w, fairness, http.StatusOK)
+}
+
+func parseStatisticsTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
 }
 
 var errTeamNotFound = &teamNotFoundError{}
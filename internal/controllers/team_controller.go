@@ -3,8 +3,10 @@ package controllers
 import (
 	"CodeRewievService/internal/models"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
 )
 
 type TeamController struct {
@@ -27,7 +29,7 @@ func (ctrl *TeamController) CreateTeam(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	existingTeam, err := ctrl.service.Get(req.TeamName)
+	existingTeam, err := ctrl.service.Get(req.TeamName, false)
 	if err == nil && existingTeam != nil {
 		ctrl.logger.Warn("Attempt to create existing team", "teamName", req.TeamName)
 		ctrl.sendConflictResponse(w, "team_name already exists")
@@ -36,7 +38,24 @@ func (ctrl *TeamController) CreateTeam(w http.ResponseWriter, r *http.Request) {
 
 	team := req.ToTeam()
 
-	if err := ctrl.service.Add(&team); err != nil {
+	err = ctrl.service.Add(r.Context(), &team)
+	if errors.Is(err, models.ErrDuplicateTeamMember) {
+		ctrl.sendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if errors.Is(err, models.ErrTeamArchived) || errors.Is(err, models.ErrInvalidTeamHierarchy) {
+		ctrl.sendConflictResponse(w, err.Error())
+		return
+	}
+
+	if errors.Is(err, models.ErrTeamNotFound) {
+		ctrl.logger.Error("Parent team not found", "teamName", req.TeamName, "parentTeam", req.ParentTeam)
+		ctrl.sendNotFoundResponse(w)
+		return
+	}
+
+	if err != nil {
 		ctrl.logger.Error("Failed to create team", "error", err, "teamName", req.TeamName)
 		ctrl.sendErrorResponse(w, "Failed to create team", http.StatusBadRequest)
 		return
@@ -56,7 +75,9 @@ func (ctrl *TeamController) GetTeam(w http.ResponseWriter, r *http.Request) {
 
 	ctrl.logger.Info("Fetching team information", "teamName", teamName)
 
-	team, err := ctrl.service.Get(teamName)
+	includeSubTeams := r.URL.Query().Get("include_subteams") == "true"
+
+	team, err := ctrl.service.Get(teamName, includeSubTeams)
 	if err != nil {
 		ctrl.logger.Error("Team not found", "error", err, "teamName", teamName)
 		ctrl.sendNotFoundResponse(w)
@@ -66,6 +87,143 @@ func (ctrl *TeamController) GetTeam(w http.ResponseWriter, r *http.Request) {
 	ctrl.sendJSONResponse(w, team, http.StatusOK)
 }
 
+func (ctrl *TeamController) SetParent(w http.ResponseWriter, r *http.Request) {
+	var req models.RequestSetParentTeam
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ctrl.sendErrorResponse(w, "Invalid request body", http.StatusBadRequest)
+		ctrl.logger.Error("Failed to decode request body", "error", err)
+		return
+	}
+
+	team, err := ctrl.service.SetParent(r.Context(), &req)
+	if errors.Is(err, models.ErrTeamNotFound) {
+		ctrl.logger.Error("Team not found", "teamName", req.TeamName, "parentTeam", req.ParentTeam)
+		ctrl.sendNotFoundResponse(w)
+		return
+	}
+
+	if errors.Is(err, models.ErrInvalidTeamHierarchy) || errors.Is(err, models.ErrTeamArchived) {
+		ctrl.sendConflictResponse(w, err.Error())
+		return
+	}
+
+	if err != nil {
+		ctrl.logger.Error("Failed to set parent team", "error", err, "teamName", req.TeamName)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, team, http.StatusOK)
+}
+
+func (ctrl *TeamController) RenameTeam(w http.ResponseWriter, r *http.Request) {
+	var req models.RequestRenameTeam
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ctrl.sendErrorResponse(w, "Invalid request body", http.StatusBadRequest)
+		ctrl.logger.Error("Failed to decode request body", "error", err)
+		return
+	}
+
+	team, err := ctrl.service.Rename(r.Context(), &req)
+	if errors.Is(err, models.ErrInvalidTeamName) {
+		ctrl.sendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if errors.Is(err, models.ErrTeamNotFound) {
+		ctrl.logger.Error("Team not found for rename", "teamName", req.TeamName)
+		ctrl.sendNotFoundResponse(w)
+		return
+	}
+
+	if errors.Is(err, models.ErrTeamAlreadyExists) {
+		ctrl.sendConflictResponse(w, "team_name already exists")
+		return
+	}
+
+	if err != nil {
+		ctrl.logger.Error("Failed to rename team", "error", err, "teamName", req.TeamName, "newName", req.NewName)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, team, http.StatusOK)
+}
+
+func (ctrl *TeamController) ListTeams(w http.ResponseWriter, r *http.Request) {
+	includeArchived := r.URL.Query().Get("include_archived") == "true"
+
+	teams, err := ctrl.service.List(includeArchived)
+	if err != nil {
+		ctrl.logger.Error("Failed to list teams", "error", err)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, models.ResponseTeamList{Teams: teams}, http.StatusOK)
+}
+
+func (ctrl *TeamController) ArchiveTeam(w http.ResponseWriter, r *http.Request) {
+	var req models.RequestArchiveTeam
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ctrl.sendErrorResponse(w, "Invalid request body", http.StatusBadRequest)
+		ctrl.logger.Error("Failed to decode request body", "error", err)
+		return
+	}
+
+	result, err := ctrl.service.Archive(r.Context(), &req)
+	if errors.Is(err, models.ErrInvalidArchivePolicy) {
+		ctrl.sendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if errors.Is(err, models.ErrTeamNotFound) {
+		ctrl.logger.Error("Team not found for archive", "teamName", req.TeamName)
+		ctrl.sendNotFoundResponse(w)
+		return
+	}
+
+	if errors.Is(err, models.ErrTeamArchived) || errors.Is(err, models.ErrInvalidTeamHierarchy) {
+		ctrl.sendConflictResponse(w, err.Error())
+		return
+	}
+
+	if err != nil {
+		ctrl.logger.Error("Failed to archive team", "error", err, "teamName", req.TeamName)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, result, http.StatusOK)
+}
+
+func (ctrl *TeamController) DeleteTeam(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		ctrl.sendErrorResponse(w, "team_name parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	err := ctrl.service.Delete(r.Context(), teamName)
+	if errors.Is(err, models.ErrTeamNotFound) {
+		ctrl.sendNotFoundResponse(w)
+		return
+	}
+
+	if errors.Is(err, models.ErrTeamNotEmpty) {
+		ctrl.sendConflictResponse(w, "team has members, sub-teams, pull requests or reviews")
+		return
+	}
+
+	if err != nil {
+		ctrl.logger.Error("Failed to delete team", "error", err, "teamName", teamName)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, map[string]string{"teamName": teamName}, http.StatusOK)
+}
+
 func (ctrl *TeamController) MassDeactivateTeamUsers(w http.ResponseWriter, r *http.Request) {
 	teamName := r.URL.Query().Get("team_name")
 	if teamName == "" {
@@ -73,18 +231,54 @@ func (ctrl *TeamController) MassDeactivateTeamUsers(w http.ResponseWriter, r *ht
 		return
 	}
 
-	if err := ctrl.service.MassDeactivateTeamUsers(teamName); err != nil {
-		ctrl.logger.Error("Failed to deactivate team users", "error", err, "teamName", teamName)
+	dryRun := r.URL.Query().Get("dryRun") == "true"
+
+	result, err := ctrl.service.MassDeactivateTeamUsers(r.Context(), teamName, dryRun)
+	if err != nil {
+		ctrl.logger.Error("Failed to deactivate team users", "error", err, "teamName", teamName, "dryRun", dryRun)
 		ctrl.sendErrorResponse(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	ctrl.sendJSONResponse(w, map[string]string{
-		"message": "Team users deactivated successfully",
-		"team":    teamName,
+	if dryRun {
+		ctrl.sendJSONResponse(w, result, http.StatusOK)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, map[string]interface{}{
+		"message":        "Team users deactivated successfully",
+		"team":           teamName,
+		"deactivationId": result.DeactivationID,
 	}, http.StatusOK)
 }
 
+func (ctrl *TeamController) ReactivateTeam(w http.ResponseWriter, r *http.Request) {
+	deactivationID, err := strconv.ParseInt(r.URL.Query().Get("deactivation_id"), 10, 64)
+	if err != nil {
+		ctrl.sendErrorResponse(w, "deactivation_id must be an integer", http.StatusBadRequest)
+		return
+	}
+
+	result, err := ctrl.service.Reactivate(r.Context(), deactivationID)
+	if errors.Is(err, models.ErrDeactivationNotFound) {
+		ctrl.sendNotFoundResponse(w)
+		return
+	}
+
+	if errors.Is(err, models.ErrDeactivationRestored) {
+		ctrl.sendConflictResponse(w, err.Error())
+		return
+	}
+
+	if err != nil {
+		ctrl.logger.Error("Failed to reactivate team", "error", err, "deactivationID", deactivationID)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, result, http.StatusOK)
+}
+
 func (ctrl *TeamController) sendJSONResponse(w http.ResponseWriter, data interface{}, statusCode int) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
@@ -3,8 +3,10 @@ package controllers
 import (
 	"CodeRewievService/internal/models"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
 )
 
 type UserController struct {
@@ -27,7 +29,7 @@ func (ctrl *UserController) SetUserIsActive(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	user, err := ctrl.service.SetIsActive(&models.User{
+	user, err := ctrl.service.SetIsActive(r.Context(), &models.User{
 		UserID:   req.UserID,
 		IsActive: req.IsActive,
 	})
@@ -41,6 +43,114 @@ func (ctrl *UserController) SetUserIsActive(w http.ResponseWriter, r *http.Reque
 	ctrl.sendJSONResponse(w, models.ResponseSetIsActive{User: *user}, http.StatusOK)
 }
 
+func (ctrl *UserController) BulkSetUserIsActive(w http.ResponseWriter, r *http.Request) {
+	var req models.RequestBulkSetIsActive
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ctrl.sendErrorResponse(w, "Invalid request body", http.StatusBadRequest)
+		ctrl.logger.Error("Failed to decode request body", "error", err)
+		return
+	}
+
+	response, err := ctrl.service.BulkSetIsActive(r.Context(), &req)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidBulkRequest) {
+			ctrl.sendErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ctrl.logger.Error("Failed to bulk set user active status", "error", err, "users", len(req.UserIDs))
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, response, http.StatusOK)
+}
+
+func (ctrl *UserController) GetUser(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		ctrl.sendErrorResponse(w, "user_id parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	response, err := ctrl.service.Get(userID)
+	if err != nil {
+		if errors.Is(err, models.ErrUserNotFound) {
+			ctrl.sendErrorResponse(w, "resource not found", http.StatusNotFound)
+			return
+		}
+
+		ctrl.logger.Error("Failed to get user", "error", err, "userID", userID)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, response, http.StatusOK)
+}
+
+func (ctrl *UserController) ListUsers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := models.UserFilter{TeamName: query.Get("team_name")}
+
+	if value := query.Get("is_active"); value != "" {
+		isActive, err := strconv.ParseBool(value)
+		if err != nil {
+			ctrl.sendErrorResponse(w, "is_active must be a boolean", http.StatusBadRequest)
+			return
+		}
+		filter.IsActive = &isActive
+	}
+
+	var err error
+	if value := query.Get("limit"); value != "" {
+		if filter.Limit, err = strconv.Atoi(value); err != nil {
+			ctrl.sendErrorResponse(w, "limit must be an integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if value := query.Get("offset"); value != "" {
+		if filter.Offset, err = strconv.Atoi(value); err != nil {
+			ctrl.sendErrorResponse(w, "offset must be an integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	response, err := ctrl.service.List(filter)
+	if err != nil {
+		ctrl.logger.Error("Failed to list users", "error", err)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, response, http.StatusOK)
+}
+
+func (ctrl *UserController) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	var req models.RequestUpdateUser
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ctrl.sendErrorResponse(w, "Invalid request body", http.StatusBadRequest)
+		ctrl.logger.Error("Failed to decode request body", "error", err)
+		return
+	}
+
+	user, err := ctrl.service.Update(r.Context(), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidUserProfile):
+			ctrl.sendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, models.ErrUserNotFound):
+			ctrl.sendErrorResponse(w, "resource not found", http.StatusNotFound)
+		default:
+			ctrl.logger.Error("Failed to update user", "error", err, "userID", req.UserID)
+			ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctrl.sendJSONResponse(w, models.ResponseSetIsActive{User: *user}, http.StatusOK)
+}
+
 func (ctrl *UserController) GetUserReview(w http.ResponseWriter, r *http.Request) {
 	userID := r.URL.Query().Get("user_id")
 	if userID == "" {
@@ -0,0 +1,169 @@
+package controllers
+
+import (
+	"CodeRewievService/internal/models"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type WebhookController struct {
+	service WebhookService
+	logger  *slog.Logger
+}
+
+func NewWebhookController(service WebhookService, logger *slog.Logger) *WebhookController {
+	return &WebhookController{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (ctrl *WebhookController) Subscribe(w http.ResponseWriter, r *http.Request) {
+	var req models.RequestCreateWebhook
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ctrl.sendErrorResponse(w, "Invalid request body", http.StatusBadRequest)
+		ctrl.logger.Error("Failed to decode request body", "error", err)
+		return
+	}
+
+	subscription, err := ctrl.service.Subscribe(&req)
+	if errors.Is(err, models.ErrInvalidWebhook) {
+		ctrl.sendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err != nil {
+		ctrl.logger.Error("Failed to create webhook subscription", "error", err, "url", req.URL)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, subscription, http.StatusCreated)
+}
+
+func (ctrl *WebhookController) ListSubscriptions(w http.ResponseWriter, _ *http.Request) {
+	subscriptions, err := ctrl.service.ListSubscriptions()
+	if err != nil {
+		ctrl.logger.Error("Failed to list webhook subscriptions", "error", err)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, models.ResponseWebhookSubscriptions{Subscriptions: subscriptions}, http.StatusOK)
+}
+
+func (ctrl *WebhookController) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	var req models.RequestDeleteWebhook
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ctrl.sendErrorResponse(w, "Invalid request body", http.StatusBadRequest)
+		ctrl.logger.Error("Failed to decode request body", "error", err)
+		return
+	}
+
+	err := ctrl.service.Unsubscribe(req.SubscriptionID)
+	if errors.Is(err, models.ErrWebhookNotFound) {
+		ctrl.sendNotFoundResponse(w)
+		return
+	}
+
+	if err != nil {
+		ctrl.logger.Error("Failed to delete webhook subscription", "error", err, "subscriptionID", req.SubscriptionID)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, map[string]int64{"subscriptionId": req.SubscriptionID}, http.StatusOK)
+}
+
+func (ctrl *WebhookController) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := models.WebhookDeliveryFilter{
+		EventType: query.Get("event"),
+		Status:    query.Get("status"),
+	}
+
+	var err error
+	if value := query.Get("subscription_id"); value != "" {
+		if filter.SubscriptionID, err = strconv.ParseInt(value, 10, 64); err != nil {
+			ctrl.sendErrorResponse(w, "subscription_id must be an integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if value := query.Get("limit"); value != "" {
+		if filter.Limit, err = strconv.Atoi(value); err != nil {
+			ctrl.sendErrorResponse(w, "limit must be an integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	deliveries, err := ctrl.service.ListDeliveries(filter)
+	if err != nil {
+		ctrl.logger.Error("Failed to list webhook deliveries", "error", err)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, models.ResponseWebhookDeliveries{Deliveries: deliveries}, http.StatusOK)
+}
+
+func (ctrl *WebhookController) Redeliver(w http.ResponseWriter, r *http.Request) {
+	var req models.RequestRedeliverWebhook
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ctrl.sendErrorResponse(w, "Invalid request body", http.StatusBadRequest)
+		ctrl.logger.Error("Failed to decode request body", "error", err)
+		return
+	}
+
+	err := ctrl.service.Redeliver(req.DeliveryID, req.Force)
+	if errors.Is(err, models.ErrWebhookDeliveryNotFound) {
+		ctrl.sendNotFoundResponse(w)
+		return
+	}
+
+	if errors.Is(err, models.ErrWebhookDeliveryNotFailed) {
+		ctrl.sendConflictResponse(w, "only failed deliveries can be redelivered; pass force to resend a delivered one")
+		return
+	}
+
+	if err != nil {
+		ctrl.logger.Error("Failed to schedule webhook redelivery", "error", err, "deliveryID", req.DeliveryID)
+		ctrl.sendErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctrl.sendJSONResponse(w, map[string]int64{"deliveryId": req.DeliveryID}, http.StatusAccepted)
+}
+
+func (ctrl *WebhookController) sendJSONResponse(w http.ResponseWriter, data interface{}, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		ctrl.logger.Error("Failed to encode JSON response", "error", err)
+	}
+}
+
+func (ctrl *WebhookController) sendErrorResponse(w http.ResponseWriter, message string, statusCode int) {
+	ctrl.sendJSONResponse(w, models.Error{
+		Code:    "ERROR",
+		Message: message,
+	}, statusCode)
+}
+
+func (ctrl *WebhookController) sendConflictResponse(w http.ResponseWriter, message string) {
+	ctrl.sendJSONResponse(w, models.Error{
+		Code:    "CONFLICT",
+		Message: message,
+	}, http.StatusConflict)
+}
+
+func (ctrl *WebhookController) sendNotFoundResponse(w http.ResponseWriter) {
+	ctrl.sendJSONResponse(w, models.Error{
+		Code:    "NOT_FOUND",
+		Message: "resource not found",
+	}, http.StatusNotFound)
+}
@@ -0,0 +1,153 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// AllEvents subscribes a handler to every published event.
+const AllEvents = "*"
+
+type Handler func(ctx context.Context, event Event) error
+
+// TxHandler reacts to an event inside the transaction of the change that caused it; its error rolls the change
+// back. It suits side effects that must not be lost on a crash, such as an outbox.
+type TxHandler func(ctx context.Context, tx *gorm.DB, event Event) error
+
+type subscription struct {
+	subscriber string
+	handler    Handler
+	async      bool
+}
+
+type txSubscription struct {
+	subscriber string
+	handler    TxHandler
+}
+
+// Bus is an in-process publish/subscribe hub. Synchronous handlers run in the publisher's goroutine in
+// registration order; asynchronous ones run in their own goroutine with a context detached from the
+// publisher's cancellation. Handler errors and panics are logged and never reach the publisher: the change
+// they react to is already committed. Transactional handlers run earlier, through PublishInTx.
+type Bus struct {
+	mu              sync.RWMutex
+	subscriptions   map[string][]subscription
+	txSubscriptions map[string][]txSubscription
+	inFlight        sync.WaitGroup
+	logger          *slog.Logger
+}
+
+func NewBus(logger *slog.Logger) *Bus {
+	return &Bus{
+		subscriptions:   make(map[string][]subscription),
+		txSubscriptions: make(map[string][]txSubscription),
+		logger:          logger,
+	}
+}
+
+func (b *Bus) Subscribe(eventName, subscriber string, handler Handler) {
+	b.subscribe(eventName, subscription{subscriber: subscriber, handler: handler})
+}
+
+func (b *Bus) SubscribeAsync(eventName, subscriber string, handler Handler) {
+	b.subscribe(eventName, subscription{subscriber: subscriber, handler: handler, async: true})
+}
+
+func (b *Bus) SubscribeInTx(eventName, subscriber string, handler TxHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.txSubscriptions[eventName] = append(b.txSubscriptions[eventName], txSubscription{
+		subscriber: subscriber,
+		handler:    handler,
+	})
+}
+
+func (b *Bus) subscribe(eventName string, sub subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subscriptions[eventName] = append(b.subscriptions[eventName], sub)
+}
+
+func (b *Bus) Publish(ctx context.Context, events ...Event) {
+	for _, event := range events {
+		for _, sub := range b.subscribersOf(event.Name()) {
+			if !sub.async {
+				b.dispatch(ctx, sub, event)
+				continue
+			}
+
+			b.inFlight.Add(1)
+			go func(sub subscription, event Event) {
+				defer b.inFlight.Done()
+				b.dispatch(context.WithoutCancel(ctx), sub, event)
+			}(sub, event)
+		}
+	}
+}
+
+// PublishInTx runs the transactional handlers of the events in the publisher's transaction, in registration
+// order, and returns the first error so that the publisher rolls the change back.
+func (b *Bus) PublishInTx(ctx context.Context, tx *gorm.DB, events ...Event) error {
+	for _, event := range events {
+		for _, sub := range b.txSubscribersOf(event.Name()) {
+			if err := sub.handler(ctx, tx, event); err != nil {
+				return fmt.Errorf("%s failed to handle %s: %w", sub.subscriber, event.Name(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Wait blocks until asynchronous handlers in flight finish or ctx is done.
+func (b *Bus) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		b.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *Bus) subscribersOf(eventName string) []subscription {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	subs := make([]subscription, 0, len(b.subscriptions[eventName])+len(b.subscriptions[AllEvents]))
+	subs = append(subs, b.subscriptions[eventName]...)
+	return append(subs, b.subscriptions[AllEvents]...)
+}
+
+func (b *Bus) txSubscribersOf(eventName string) []txSubscription {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	subs := make([]txSubscription, 0, len(b.txSubscriptions[eventName])+len(b.txSubscriptions[AllEvents]))
+	subs = append(subs, b.txSubscriptions[eventName]...)
+	return append(subs, b.txSubscriptions[AllEvents]...)
+}
+
+func (b *Bus) dispatch(ctx context.Context, sub subscription, event Event) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			b.logger.Error("Domain event handler panicked",
+				"event", event.Name(), "subscriber", sub.subscriber, "panic", fmt.Sprint(recovered))
+		}
+	}()
+
+	if err := sub.handler(ctx, event); err != nil {
+		b.logger.Error("Domain event handler failed", "event", event.Name(), "subscriber", sub.subscriber, "error", err)
+	}
+}
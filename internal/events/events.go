@@ -0,0 +1,114 @@
+package events
+
+import (
+	"CodeRewievService/internal/models"
+	"time"
+)
+
+const (
+	PRCreatedName        = "pull_request.created"
+	ReviewerAssignedName = "reviewer.assigned"
+	ReviewerReplacedName = "reviewer.replaced"
+	PRMergedName         = "pull_request.merged"
+	UserDeactivatedName  = "user.deactivated"
+	TeamDeactivatedName  = "team.deactivated"
+	TeamArchivedName     = "team.archived"
+	TeamRenamedName      = "team.renamed"
+	LoadImbalancedName   = "review.load_imbalanced"
+)
+
+// Event is a domain fact published by services inside the transaction of the change that caused it, to
+// transactional subscribers, and again after the change has been committed.
+type Event interface {
+	Name() string
+}
+
+type PRCreated struct {
+	PullRequest models.PullRequestDTO
+}
+
+type ReviewerAssigned struct {
+	PullRequestID string
+	ReviewerID    string
+	Reason        string
+}
+
+// ReviewerReplaced has an empty NewReviewerID when the review was withdrawn without a replacement.
+type ReviewerReplaced struct {
+	PullRequestID string
+	OldReviewerID string
+	NewReviewerID string
+	Reason        string
+}
+
+type PRMerged struct {
+	PullRequest models.PullRequestDTO
+	MergedAt    time.Time
+}
+
+type UserDeactivated struct {
+	UserID   string
+	TeamName string
+}
+
+type TeamDeactivated struct {
+	TeamName               string
+	DeactivatedUserIDs     []string
+	AffectedPullRequestIDs []string
+}
+
+type TeamArchived struct {
+	TeamName             string
+	Policy               string
+	DeactivatedUserIDs   []string
+	ClosedPullRequestIDs []string
+}
+
+type TeamRenamed struct {
+	OldName string
+	NewName string
+}
+
+type LoadImbalanced struct {
+	TeamName  string
+	Gini      float64
+	Threshold float64
+	Open      models.LoadDistribution
+	Members   []models.MemberLoad
+}
+
+func (PRCreated) Name() string {
+	return PRCreatedName
+}
+
+func (ReviewerAssigned) Name() string {
+	return ReviewerAssignedName
+}
+
+func (ReviewerReplaced) Name() string {
+	return ReviewerReplacedName
+}
+
+func (PRMerged) Name() string {
+	return PRMergedName
+}
+
+func (UserDeactivated) Name() string {
+	return UserDeactivatedName
+}
+
+func (TeamDeactivated) Name() string {
+	return TeamDeactivatedName
+}
+
+func (TeamArchived) Name() string {
+	return TeamArchivedName
+}
+
+func (TeamRenamed) Name() string {
+	return TeamRenamedName
+}
+
+func (LoadImbalanced) Name() string {
+	return LoadImbalancedName
+}
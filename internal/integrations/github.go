@@ -0,0 +1,122 @@
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	GitHubEventHeader     = "X-GitHub-Event"
+	GitHubDeliveryHeader  = "X-GitHub-Delivery"
+	GitHubSignatureHeader = "X-Hub-Signature-256"
+
+	GitHubActionOpened         = "opened"
+	GitHubActionReopened       = "reopened"
+	GitHubActionClosed         = "closed"
+	GitHubActionReadyForReview = "ready_for_review"
+	GitHubActionSubmitted      = "submitted"
+
+	GitHubReviewStateApproved         = "approved"
+	GitHubReviewStateChangesRequested = "changes_requested"
+
+	DefaultGitHubAPIBaseURL = "https://api.github.com"
+	defaultHTTPTimeout      = 10 * time.Second
+)
+
+// GitHubPullRequestEvent is the payload of both pull_request and pull_request_review events; Review is set only
+// for the latter.
+type GitHubPullRequestEvent struct {
+	Action      string            `json:"action"`
+	Number      int               `json:"number"`
+	PullRequest GitHubPullRequest `json:"pull_request"`
+	Review      GitHubReview      `json:"review"`
+	Repository  GitHubRepository  `json:"repository"`
+	Sender      GitHubUser        `json:"sender"`
+}
+
+type GitHubReview struct {
+	State string     `json:"state"`
+	User  GitHubUser `json:"user"`
+}
+
+type GitHubPullRequest struct {
+	Number int        `json:"number"`
+	Title  string     `json:"title"`
+	Draft  bool       `json:"draft"`
+	Merged bool       `json:"merged"`
+	User   GitHubUser `json:"user"`
+}
+
+type GitHubRepository struct {
+	FullName string `json:"full_name"`
+}
+
+type GitHubUser struct {
+	Login string `json:"login"`
+}
+
+// GitHubPullRequestID is the service-side identity of a GitHub pull request: "owner/repo#number".
+func GitHubPullRequestID(repositoryFullName string, number int) string {
+	return fmt.Sprintf("%s#%d", repositoryFullName, number)
+}
+
+type GitHubClient struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+func NewGitHubClient(baseURL, token string, client *http.Client) *GitHubClient {
+	if baseURL == "" {
+		baseURL = DefaultGitHubAPIBaseURL
+	}
+	if client == nil {
+		client = &http.Client{Timeout: defaultHTTPTimeout}
+	}
+
+	return &GitHubClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		http:    client,
+	}
+}
+
+// RequestReviewers mirrors the service's reviewer assignment as a GitHub review request.
+func (c *GitHubClient) RequestReviewers(ctx context.Context, repositoryFullName string, number int, logins []string) error {
+	body, err := json.Marshal(map[string][]string{"reviewers": logins})
+	if err != nil {
+		return fmt.Errorf("failed to encode review request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/pulls/%d/requested_reviewers", c.baseURL, repositoryFullName, number)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build review request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send review request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("github responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package integrations
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+const (
+	GitLabEventHeader          = "X-Gitlab-Event"
+	GitLabTokenHeader          = "X-Gitlab-Token"
+	GitLabEventUUIDHeader      = "X-Gitlab-Event-UUID"
+	GitLabIdempotencyKeyHeader = "Idempotency-Key"
+
+	GitLabObjectKindMergeRequest = "merge_request"
+
+	GitLabActionOpen   = "open"
+	GitLabActionUpdate = "update"
+	GitLabActionMerge  = "merge"
+	GitLabActionClose  = "close"
+	GitLabActionReopen = "reopen"
+	// GitLabActionApproved is sent when a user approves the merge request; GitLab has no "changes requested" hook.
+	GitLabActionApproved = "approved"
+)
+
+type GitLabMergeRequestEvent struct {
+	ObjectKind       string                       `json:"object_kind"`
+	User             GitLabUser                   `json:"user"`
+	Project          GitLabProject                `json:"project"`
+	ObjectAttributes GitLabMergeRequestAttributes `json:"object_attributes"`
+	Changes          GitLabMergeRequestChanges    `json:"changes"`
+}
+
+type GitLabUser struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+}
+
+type GitLabProject struct {
+	PathWithNamespace string `json:"path_with_namespace"`
+}
+
+type GitLabMergeRequestAttributes struct {
+	IID            int    `json:"iid"`
+	Title          string `json:"title"`
+	Action         string `json:"action"`
+	AuthorID       int64  `json:"author_id"`
+	Draft          bool   `json:"draft"`
+	WorkInProgress bool   `json:"work_in_progress"`
+}
+
+type GitLabMergeRequestChanges struct {
+	Draft          *GitLabBoolChange `json:"draft"`
+	WorkInProgress *GitLabBoolChange `json:"work_in_progress"`
+}
+
+type GitLabBoolChange struct {
+	Previous bool `json:"previous"`
+	Current  bool `json:"current"`
+}
+
+// IsDraft covers both the current "draft" attribute and the legacy "work_in_progress" one.
+func (a GitLabMergeRequestAttributes) IsDraft() bool {
+	return a.Draft || a.WorkInProgress
+}
+
+// DraftChange reports whether an update toggled the draft state and what the new state is.
+func (c GitLabMergeRequestChanges) DraftChange() (bool, bool) {
+	if c.Draft != nil {
+		return true, c.Draft.Current
+	}
+	if c.WorkInProgress != nil {
+		return true, c.WorkInProgress.Current
+	}
+	return false, false
+}
+
+// AuthorLogins lists the logins the author may be mapped under: the username is known only when the author
+// triggered the event, the numeric GitLab user ID always is.
+func (e GitLabMergeRequestEvent) AuthorLogins() []string {
+	logins := make([]string, 0, 2)
+	if e.User.Username != "" && e.User.ID == e.ObjectAttributes.AuthorID {
+		logins = append(logins, e.User.Username)
+	}
+	if e.ObjectAttributes.AuthorID != 0 {
+		logins = append(logins, fmt.Sprintf("%d", e.ObjectAttributes.AuthorID))
+	}
+	return logins
+}
+
+// UserLogins lists the logins the user who triggered the event may be mapped under: the username and the
+// numeric GitLab user ID.
+func (e GitLabMergeRequestEvent) UserLogins() []string {
+	logins := make([]string, 0, 2)
+	if e.User.Username != "" {
+		logins = append(logins, e.User.Username)
+	}
+	if e.User.ID != 0 {
+		logins = append(logins, fmt.Sprintf("%d", e.User.ID))
+	}
+	return logins
+}
+
+// GitLabPullRequestID is the service-side identity of a merge request, in GitLab's own "group/project!iid" notation.
+func GitLabPullRequestID(projectPath string, iid int) string {
+	return fmt.Sprintf("%s!%d", projectPath, iid)
+}
+
+// GitLabDeliveryID picks the header GitLab keeps stable across retries; replays of a payload without
+// such headers are recognised by the body hash.
+func GitLabDeliveryID(idempotencyKey, eventUUID string, body []byte) string {
+	if idempotencyKey != "" {
+		return idempotencyKey
+	}
+	if eventUUID != "" {
+		return eventUUID
+	}
+
+	sum := sha256.Sum256(body)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,15 @@
+package integrations
+
+const (
+	ProviderGitHub = "github"
+	ProviderGitLab = "gitlab"
+)
+
+func IsKnownProvider(provider string) bool {
+	switch provider {
+	case ProviderGitHub, ProviderGitLab:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,167 @@
+package manifest
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	FormatYAML = "yaml"
+	FormatCSV  = "csv"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported manifest format")
+	ErrDuplicateUser     = errors.New("user appears more than once in the manifest")
+)
+
+type Manifest struct {
+	Teams []Team `yaml:"teams"`
+}
+
+type Team struct {
+	Name    string   `yaml:"name"`
+	Members []Member `yaml:"members"`
+}
+
+type Member struct {
+	UserID   string `yaml:"id"`
+	Username string `yaml:"username"`
+	// IsActive defaults to true; HR may keep leavers listed as inactive.
+	IsActive *bool `yaml:"active"`
+}
+
+func (m Member) Active() bool {
+	return m.IsActive == nil || *m.IsActive
+}
+
+func Parse(format string, reader io.Reader) (*Manifest, error) {
+	var (
+		parsed *Manifest
+		err    error
+	)
+
+	switch strings.ToLower(format) {
+	case FormatYAML, "yml":
+		parsed, err = ParseYAML(reader)
+	case FormatCSV:
+		parsed, err = ParseCSV(reader)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parsed, parsed.Validate()
+}
+
+// ParseYAML reads a "teams: [{name, members: [{id, username, active}]}]" document.
+func ParseYAML(reader io.Reader) (*Manifest, error) {
+	var parsed Manifest
+	decoder := yaml.NewDecoder(reader)
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(&parsed); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read yaml: %w", err)
+	}
+
+	return &parsed, nil
+}
+
+// ParseCSV reads "team_name,user_id,username[,is_active]" rows; a header row is skipped.
+// Teams keep the order of their first appearance.
+func ParseCSV(reader io.Reader) (*Manifest, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	parsed := &Manifest{}
+	teamIndex := make(map[string]int)
+
+	for line := 1; ; line++ {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read csv: %w", err)
+		}
+
+		if len(record) == 0 || (len(record) == 1 && strings.TrimSpace(record[0]) == "") {
+			continue
+		}
+
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "team_name") {
+			continue
+		}
+
+		if len(record) < 3 {
+			return nil, fmt.Errorf("line %d: expected team_name,user_id,username[,is_active]", line)
+		}
+
+		member := Member{
+			UserID:   strings.TrimSpace(record[1]),
+			Username: strings.TrimSpace(record[2]),
+		}
+
+		if len(record) > 3 && strings.TrimSpace(record[3]) != "" {
+			active, err := strconv.ParseBool(strings.TrimSpace(record[3]))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid is_active value %q", line, record[3])
+			}
+			member.IsActive = &active
+		}
+
+		teamName := strings.TrimSpace(record[0])
+		index, ok := teamIndex[teamName]
+		if !ok {
+			index = len(parsed.Teams)
+			teamIndex[teamName] = index
+			parsed.Teams = append(parsed.Teams, Team{Name: teamName})
+		}
+		parsed.Teams[index].Members = append(parsed.Teams[index].Members, member)
+	}
+
+	return parsed, nil
+}
+
+// Validate rejects manifests the sync cannot apply unambiguously: unnamed teams, incomplete members
+// and users listed in several places.
+func (m *Manifest) Validate() error {
+	if len(m.Teams) == 0 {
+		return errors.New("manifest contains no teams")
+	}
+
+	seenTeams := make(map[string]bool, len(m.Teams))
+	seenUsers := make(map[string]string)
+
+	for _, team := range m.Teams {
+		if team.Name == "" {
+			return errors.New("team name cannot be empty")
+		}
+		if seenTeams[team.Name] {
+			return fmt.Errorf("team %q appears more than once", team.Name)
+		}
+		seenTeams[team.Name] = true
+
+		for _, member := range team.Members {
+			if member.UserID == "" || member.Username == "" {
+				return fmt.Errorf("team %q: member id and username are required", team.Name)
+			}
+			if otherTeam, ok := seenUsers[member.UserID]; ok {
+				return fmt.Errorf("%w: %q in teams %q and %q", ErrDuplicateUser, member.UserID, otherTeam, team.Name)
+			}
+			seenUsers[member.UserID] = team.Name
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	AuditTeamCreate             = "team.create"
+	AuditTeamMassDeactivate     = "team.mass_deactivate"
+	AuditTeamReactivate         = "team.reactivate"
+	AuditTeamManifestApply      = "team.manifest_apply"
+	AuditTeamAddMember          = "team.add_member"
+	AuditTeamRemoveMember       = "team.remove_member"
+	AuditTeamMoveMember         = "team.move_member"
+	AuditTeamSetParent          = "team.set_parent"
+	AuditTeamArchive            = "team.archive"
+	AuditTeamDelete             = "team.delete"
+	AuditTeamRename             = "team.rename"
+	AuditUserSetIsActive        = "user.set_is_active"
+	AuditUserUpdate             = "user.update"
+	AuditPullRequestCreate      = "pull_request.create"
+	AuditPullRequestMerge       = "pull_request.merge"
+	AuditPullRequestReassign    = "pull_request.reassign"
+	AuditPullRequestAddReviewer = "pull_request.add_reviewer"
+	AuditPullRequestClose       = "pull_request.close"
+	AuditPullRequestReopen      = "pull_request.reopen"
+	AuditPullRequestReview      = "pull_request.review"
+	AuditScheduledJobCreate     = "scheduled_job.create"
+	AuditScheduledJobCancel     = "scheduled_job.cancel"
+
+	AuditEntityTeam         = "team"
+	AuditEntityUser         = "user"
+	AuditEntityPullRequest  = "pull_request"
+	AuditEntityScheduledJob = "scheduled_job"
+)
+
+type AuditRecord struct {
+	ID         int64           `gorm:"primaryKey;column:id" json:"id"`
+	Actor      string          `gorm:"not null;column:actor" json:"actor"`
+	Operation  string          `gorm:"not null;column:operation" json:"operation"`
+	EntityType string          `gorm:"not null;column:entity_type" json:"entityType"`
+	EntityID   string          `gorm:"not null;column:entity_id" json:"entityId"`
+	Before     json.RawMessage `gorm:"type:jsonb;column:before" json:"before,omitempty"`
+	After      json.RawMessage `gorm:"type:jsonb;column:after" json:"after,omitempty"`
+	RequestID  string          `gorm:"not null;column:request_id" json:"requestId,omitempty"`
+	CreatedAt  time.Time       `gorm:"autoCreateTime;column:created_at" json:"createdAt"`
+}
+
+// AuditChange is one entity's before and after snapshots within a batch operation.
+type AuditChange struct {
+	EntityID string
+	Before   interface{}
+	After    interface{}
+}
+
+type AuditFilter struct {
+	Actor      string
+	Operation  string
+	EntityType string
+	EntityID   string
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+}
+
+type ResponseAuditRecords struct {
+	Records []AuditRecord `json:"records"`
+}
+
+type PullRequestSnapshot struct {
+	PullRequestID     string     `json:"pullRequestId"`
+	PullRequestName   string     `json:"pullRequestName"`
+	AuthorID          string     `json:"authorId"`
+	TeamName          string     `json:"teamName,omitempty"`
+	Status            string     `json:"status"`
+	AssignedReviewers []string   `json:"assignedReviewers"`
+	MergedAt          *time.Time `json:"mergedAt,omitempty"`
+}
+
+type TeamDeactivationSnapshot struct {
+	TeamName               string   `json:"teamName"`
+	DeactivationID         int64    `json:"deactivationId,omitempty"`
+	ActiveUserIDs          []string `json:"activeUserIds,omitempty"`
+	DeactivatedUserIDs     []string `json:"deactivatedUserIds,omitempty"`
+	AffectedPullRequestIDs []string `json:"affectedPullRequestIds,omitempty"`
+}
+
+func (pr *PullRequest) ToSnapshot() PullRequestSnapshot {
+	reviewerIDs := make([]string, len(pr.AssignedReviewers))
+	for i, reviewer := range pr.AssignedReviewers {
+		reviewerIDs[i] = reviewer.UserID
+	}
+
+	return PullRequestSnapshot{
+		PullRequestID:     pr.PullRequestID,
+		PullRequestName:   pr.PullRequestName,
+		AuthorID:          pr.AuthorID,
+		TeamName:          pr.TeamName,
+		Status:            pr.Status,
+		AssignedReviewers: reviewerIDs,
+		MergedAt:          pr.MergedAt,
+	}
+}
+
+func (AuditRecord) TableName() string {
+	return "audit_log"
+}
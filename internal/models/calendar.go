@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+type TeamCalendar struct {
+	TeamName        string    `gorm:"primaryKey;column:team_name"`
+	Timezone        string    `gorm:"not null;column:timezone"`
+	WorkDays        string    `gorm:"not null;column:work_days"`
+	WorkStartMinute int       `gorm:"not null;column:work_start_minute"`
+	WorkEndMinute   int       `gorm:"not null;column:work_end_minute"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime;column:updated_at"`
+}
+
+type TeamHoliday struct {
+	TeamName    string    `gorm:"primaryKey;column:team_name" json:"-"`
+	HolidayDate time.Time `gorm:"primaryKey;type:date;column:holiday_date" json:"date"`
+	Name        string    `gorm:"not null;column:name" json:"name"`
+}
+
+type TeamCalendarDTO struct {
+	TeamName  string        `json:"teamName"`
+	Timezone  string        `json:"timezone"`
+	WorkDays  []int         `json:"workDays"`
+	WorkStart string        `json:"workStart"`
+	WorkEnd   string        `json:"workEnd"`
+	Holidays  []TeamHoliday `json:"holidays"`
+}
+
+type RequestSetTeamCalendar struct {
+	TeamName  string `json:"teamName"`
+	Timezone  string `json:"timezone"`
+	WorkDays  []int  `json:"workDays"`
+	WorkStart string `json:"workStart"`
+	WorkEnd   string `json:"workEnd"`
+}
+
+type ResponseImportHolidays struct {
+	TeamName string `json:"teamName"`
+	Imported int    `json:"imported"`
+}
+
+func (TeamCalendar) TableName() string {
+	return "team_calendars"
+}
+
+func (TeamHoliday) TableName() string {
+	return "team_holidays"
+}
@@ -3,11 +3,14 @@ package models
 import "time"
 
 type PullRequestDTO struct {
-	PullRequestID     string   `json:"pullRequestId"`
-	PullRequestName   string   `json:"pullRequestName"`
-	AuthorID          string   `json:"authorId"`
-	Status            string   `json:"status"`
-	AssignedReviewers []string `json:"assignedReviewers,omitempty"`
+	PullRequestID     string    `json:"pullRequestId"`
+	PullRequestName   string    `json:"pullRequestName"`
+	AuthorID          string    `json:"authorId"`
+	TeamName          string    `json:"teamName,omitempty"`
+	Status            string    `json:"status"`
+	AssignedReviewers []string  `json:"assignedReviewers,omitempty"`
+	CreatedAt         time.Time `json:"createdAt"`
+	AgeMinutes        int64     `json:"ageBusinessMinutes"`
 }
 
 func (pr *PullRequest) ToResponse() PullRequestDTO {
@@ -20,8 +23,11 @@ func (pr *PullRequest) ToResponse() PullRequestDTO {
 		PullRequestID:     pr.PullRequestID,
 		PullRequestName:   pr.PullRequestName,
 		AuthorID:          pr.AuthorID,
+		TeamName:          pr.TeamName,
 		Status:            pr.Status,
 		AssignedReviewers: reviewerIDs,
+		CreatedAt:         pr.CreatedAt,
+		AgeMinutes:        int64(pr.BusinessAge / time.Minute),
 	}
 }
 
@@ -46,3 +52,21 @@ type ResponseAddTeam struct {
 type ResponseSetIsActive struct {
 	User User `json:"user"`
 }
+
+const (
+	BulkUserChanged   = "changed"
+	BulkUserUnchanged = "unchanged"
+	BulkUserNotFound  = "not_found"
+)
+
+type BulkUserResult struct {
+	UserID string `json:"userId"`
+	Status string `json:"status"`
+}
+
+// ResponseBulkSetIsActive lists one result per distinct requested user, in the order of first appearance.
+type ResponseBulkSetIsActive struct {
+	IsActive          bool                 `json:"isActive"`
+	Results           []BulkUserResult     `json:"results"`
+	ReassignedReviews []ReviewReassignment `json:"reassignedReviews"`
+}
@@ -8,8 +8,44 @@ var (
 	ErrPullRequestNotFound      = errors.New("PULL REQUEST NOT FOUND")
 	ErrPullRequestAlreadyMerged = errors.New("PULL REQUEST ALREADY MERGED")
 	ErrReviewerNotAssigned      = errors.New("REVIEWER IS NOT ASSIGNED TO PULL REQUEST")
+	ErrInvalidReviewVerdict     = errors.New("INVALID REVIEW VERDICT")
 	ErrNoReplacementFound       = errors.New("NO REPLACEMENT REVIEWER FOUND")
 	ErrTeamAlreadyExists        = errors.New("TEAM ALREADY EXISTS")
+	ErrTeamNotFound             = errors.New("TEAM NOT FOUND")
+	ErrInvalidTeamName          = errors.New("INVALID TEAM NAME")
+	ErrDuplicateTeamMember      = errors.New("DUPLICATE TEAM MEMBER")
+	ErrDeactivationNotFound     = errors.New("TEAM DEACTIVATION NOT FOUND")
+	ErrDeactivationRestored     = errors.New("TEAM DEACTIVATION IS ALREADY RESTORED")
+	ErrInvalidScheduledJob      = errors.New("INVALID SCHEDULED JOB")
+	ErrScheduledJobNotFound     = errors.New("SCHEDULED JOB NOT FOUND")
+	ErrScheduledJobNotPending   = errors.New("SCHEDULED JOB IS NOT PENDING")
+	ErrInvalidTeamHierarchy     = errors.New("INVALID TEAM HIERARCHY")
+	ErrTeamArchived             = errors.New("TEAM IS ARCHIVED")
+	ErrTeamNotEmpty             = errors.New("TEAM IS NOT EMPTY")
+	ErrInvalidArchivePolicy     = errors.New("INVALID ARCHIVE POLICY")
+	ErrInvalidSLA               = errors.New("INVALID REVIEW SLA")
+	ErrSLANotFound              = errors.New("REVIEW SLA NOT FOUND")
+	ErrInvalidCalendar          = errors.New("INVALID TEAM CALENDAR")
+	ErrInvalidHolidaysFile      = errors.New("INVALID HOLIDAYS FILE")
+	ErrUserNotFound             = errors.New("USER NOT FOUND")
+	ErrInvalidNotificationPrefs = errors.New("INVALID NOTIFICATION PREFERENCES")
+	ErrInvalidWebhook           = errors.New("INVALID WEBHOOK SUBSCRIPTION")
+	ErrWebhookNotFound          = errors.New("WEBHOOK SUBSCRIPTION NOT FOUND")
+	ErrWebhookDeliveryNotFound  = errors.New("WEBHOOK DELIVERY NOT FOUND")
+	ErrWebhookDeliveryNotFailed = errors.New("WEBHOOK DELIVERY IS NOT FAILED")
+	ErrPullRequestClosed        = errors.New("PULL REQUEST IS CLOSED")
+	ErrInvalidSignature         = errors.New("INVALID WEBHOOK SIGNATURE")
+	ErrIntegrationDisabled      = errors.New("INTEGRATION IS NOT CONFIGURED")
+	ErrExternalUserNotMapped    = errors.New("EXTERNAL USER IS NOT MAPPED")
+	ErrInvalidUserMapping       = errors.New("INVALID EXTERNAL USER MAPPING")
+	ErrInvalidIntegrationEvent  = errors.New("INVALID INTEGRATION EVENT")
+	ErrInvalidManifest          = errors.New("INVALID TEAMS MANIFEST")
+	ErrAlreadyTeamMember        = errors.New("USER IS ALREADY A TEAM MEMBER")
+	ErrNotTeamMember            = errors.New("USER IS NOT A TEAM MEMBER")
+	ErrInvalidMembershipChange  = errors.New("INVALID MEMBERSHIP CHANGE")
+	ErrInvalidBulkRequest       = errors.New("INVALID BULK REQUEST")
+	ErrInvalidUserProfile       = errors.New("INVALID USER PROFILE")
+	ErrInvalidStatisticsRequest = errors.New("INVALID STATISTICS REQUEST")
 )
 
 type Error struct {
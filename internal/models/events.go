@@ -0,0 +1,35 @@
+package models
+
+const (
+	EventReviewerAssigned   = "pull_request.reviewer_assigned"
+	EventReviewerUnassigned = "pull_request.reviewer_unassigned"
+	EventReviewersAssigned  = "pull_request.reviewers_assigned"
+	EventReviewerReplaced   = "reviewer.replaced"
+	EventPullRequestMerged  = "pull_request.merged"
+	EventUserDeactivated    = "user.deactivated"
+	EventTeamDeactivated    = "team.deactivated"
+	EventTeamArchived       = "team.archived"
+	EventTeamRenamed        = "team.renamed"
+	EventReviewSLABreached  = "review.sla_breached"
+	EventLoadImbalanced     = "review.load_imbalanced"
+)
+
+// WebhookEventTypes are the events integrators can subscribe to.
+var WebhookEventTypes = []string{
+	EventReviewersAssigned,
+	EventReviewerReplaced,
+	EventPullRequestMerged,
+	EventTeamDeactivated,
+	EventTeamArchived,
+	EventTeamRenamed,
+	EventLoadImbalanced,
+}
+
+func IsWebhookEventType(eventType string) bool {
+	for _, known := range WebhookEventTypes {
+		if known == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// MemberLoad is the review load of one active member: open reviews and all reviews ever assigned on behalf
+// of the team.
+type MemberLoad struct {
+	UserID          string `json:"userId"`
+	Username        string `json:"userName"`
+	OpenReviews     int    `json:"openReviews"`
+	AssignedReviews int    `json:"assignedReviews"`
+}
+
+// LoadDistribution summarises how evenly a load is spread. MaxMinRatio is omitted when the least loaded
+// member has nothing while others do; Gini is 0 for a perfectly even load and approaches 1 when one member
+// carries all of it.
+type LoadDistribution struct {
+	Total       int      `json:"total"`
+	Mean        float64  `json:"mean"`
+	StdDev      float64  `json:"stdDev"`
+	Min         int      `json:"min"`
+	Max         int      `json:"max"`
+	MaxMinRatio *float64 `json:"maxMinRatio,omitempty"`
+	Gini        float64  `json:"gini"`
+}
+
+type TeamFairness struct {
+	TeamName   string           `json:"teamName"`
+	Members    []MemberLoad     `json:"members"`
+	Open       LoadDistribution `json:"open"`
+	Historical LoadDistribution `json:"historical"`
+	Threshold  float64          `json:"imbalanceThreshold"`
+	Imbalanced bool             `json:"imbalanced"`
+}
+
+// TeamLoadImbalanceAlert marks a team whose open review load is above the imbalance threshold; it is removed
+// once the load evens out again.
+type TeamLoadImbalanceAlert struct {
+	TeamName        string    `gorm:"primaryKey;column:team_name" json:"teamName"`
+	GiniCoefficient float64   `gorm:"not null;column:gini_coefficient" json:"giniCoefficient"`
+	Threshold       float64   `gorm:"not null;column:threshold" json:"threshold"`
+	RaisedAt        time.Time `gorm:"not null;column:raised_at" json:"raisedAt"`
+}
+
+func (TeamLoadImbalanceAlert) TableName() string {
+	return "team_load_imbalance_alerts"
+}
@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+type ExternalUserMapping struct {
+	Provider      string `gorm:"primaryKey;column:provider" json:"provider"`
+	ExternalLogin string `gorm:"primaryKey;column:external_login" json:"externalLogin"`
+	UserID        string `gorm:"not null;column:user_id" json:"userId"`
+}
+
+type IntegrationDelivery struct {
+	Provider   string    `gorm:"primaryKey;column:provider" json:"provider"`
+	DeliveryID string    `gorm:"primaryKey;column:delivery_id" json:"deliveryId"`
+	Event      string    `gorm:"not null;column:event" json:"event"`
+	Action     string    `gorm:"not null;column:action" json:"action"`
+	Result     string    `gorm:"not null;column:result" json:"result"`
+	ReceivedAt time.Time `gorm:"autoCreateTime;column:received_at" json:"receivedAt"`
+}
+
+type IntegrationResult struct {
+	Provider      string `json:"provider"`
+	DeliveryID    string `json:"deliveryId"`
+	Event         string `json:"event"`
+	Action        string `json:"action"`
+	PullRequestID string `json:"pullRequestId,omitempty"`
+	Result        string `json:"result"`
+	Duplicate     bool   `json:"duplicate"`
+}
+
+type RequestMapExternalUser struct {
+	Provider      string `json:"provider"`
+	ExternalLogin string `json:"externalLogin"`
+	UserID        string `json:"userId"`
+}
+
+type ResponseExternalUserMappings struct {
+	Mappings []ExternalUserMapping `json:"mappings"`
+}
+
+func (ExternalUserMapping) TableName() string {
+	return "external_user_mappings"
+}
+
+func (IntegrationDelivery) TableName() string {
+	return "integration_deliveries"
+}
@@ -0,0 +1,26 @@
+package models
+
+type ManifestUserChange struct {
+	UserID           string `json:"userId"`
+	Username         string `json:"userName"`
+	TeamName         string `json:"teamName"`
+	PreviousTeam     string `json:"previousTeamName,omitempty"`
+	PreviousUsername string `json:"previousUserName,omitempty"`
+}
+
+type ReviewReassignment struct {
+	PullRequestID string `json:"pullRequestId"`
+	OldReviewerID string `json:"oldReviewerId"`
+	NewReviewerID string `json:"newReviewerId,omitempty"`
+}
+
+type ManifestDiff struct {
+	DryRun            bool                 `json:"dryRun"`
+	NewTeams          []string             `json:"newTeams"`
+	NewUsers          []ManifestUserChange `json:"newUsers"`
+	MovedUsers        []ManifestUserChange `json:"movedUsers"`
+	RenamedUsers      []ManifestUserChange `json:"renamedUsers"`
+	ReactivatedUsers  []ManifestUserChange `json:"reactivatedUsers"`
+	DeactivatedUsers  []ManifestUserChange `json:"deactivatedUsers"`
+	ReassignedReviews []ReviewReassignment `json:"reassignedReviews"`
+}
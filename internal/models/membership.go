@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+const (
+	MembershipPolicyKeep     = "keep"
+	MembershipPolicyReassign = "reassign"
+)
+
+// TeamMembership links a user to one of their teams; at most one membership per user is primary and
+// mirrored in users.team_name.
+type TeamMembership struct {
+	UserID    string    `gorm:"primaryKey;column:user_id" json:"userId"`
+	TeamName  string    `gorm:"primaryKey;column:team_name" json:"teamName"`
+	IsPrimary bool      `gorm:"not null;default:false;column:is_primary" json:"isPrimary"`
+	JoinedAt  time.Time `gorm:"autoCreateTime;column:joined_at" json:"joinedAt"`
+}
+
+// ReviewerCandidate is an active user eligible to review, with the team the review will count towards.
+type ReviewerCandidate struct {
+	User
+	ViaTeam string `gorm:"column:via_team"`
+}
+
+// RequestAddTeamMember makes the team primary when Primary is set or the user has no primary team yet.
+type RequestAddTeamMember struct {
+	TeamName string `json:"teamName"`
+	UserID   string `json:"userId"`
+	Username string `json:"username"`
+	IsActive *bool  `json:"isActive"`
+	Primary  bool   `json:"primary"`
+}
+
+// RequestRemoveTeamMember and RequestMoveTeamMember choose what happens to the user's open reviews and to the
+// reviewers of the PRs they authored: "reassign" (default) or "keep".
+type RequestRemoveTeamMember struct {
+	TeamName string `json:"teamName"`
+	UserID   string `json:"userId"`
+	Policy   string `json:"policy"`
+}
+
+type RequestMoveTeamMember struct {
+	UserID   string `json:"userId"`
+	FromTeam string `json:"fromTeam"`
+	ToTeam   string `json:"toTeam"`
+	Policy   string `json:"policy"`
+}
+
+type MembershipChange struct {
+	User              User                 `json:"user"`
+	Teams             []TeamMembership     `json:"teams"`
+	PreviousTeam      string               `json:"previousTeam,omitempty"`
+	Policy            string               `json:"policy,omitempty"`
+	ReassignedReviews []ReviewReassignment `json:"reassignedReviews"`
+}
+
+func IsKnownMembershipPolicy(policy string) bool {
+	return policy == MembershipPolicyKeep || policy == MembershipPolicyReassign
+}
+
+func (TeamMembership) TableName() string {
+	return "team_memberships"
+}
@@ -3,32 +3,51 @@ package models
 import "time"
 
 type Team struct {
-	TeamName string `gorm:"primaryKey;column:team_name" json:"teamName"`
-	Members  []User `gorm:"-" json:"teamMembers"`
+	TeamName   string     `gorm:"primaryKey;column:team_name" json:"teamName"`
+	ParentTeam string     `gorm:"default:null;column:parent_team" json:"parentTeam,omitempty"`
+	ArchivedAt *time.Time `gorm:"column:archived_at" json:"archivedAt,omitempty"`
+	Members    []User     `gorm:"-" json:"teamMembers"`
+	SubTeams   []string   `gorm:"-" json:"subTeams,omitempty"`
 }
 
 type User struct {
-	UserID   string `gorm:"primaryKey;column:user_id" json:"userId"`
-	Username string `gorm:"not null;column:username" json:"userName"`
-	TeamName string `gorm:"not null;column:team_name;index" json:"teamName"`
-	IsActive bool   `gorm:"default:true;column:is_active" json:"isActive"`
+	UserID      string `gorm:"primaryKey;column:user_id" json:"userId"`
+	Username    string `gorm:"not null;column:username" json:"userName"`
+	TeamName    string `gorm:"column:team_name;index" json:"teamName"`
+	IsActive    bool   `gorm:"default:true;column:is_active" json:"isActive"`
+	Email       string `gorm:"not null;default:'';column:email" json:"email,omitempty"`
+	DisplayName string `gorm:"not null;default:'';column:display_name" json:"displayName,omitempty"`
+	ChatHandle  string `gorm:"not null;default:'';column:chat_handle" json:"chatHandle,omitempty"`
+	Timezone    string `gorm:"not null;default:'';column:timezone" json:"timezone,omitempty"`
 }
 
 type PullRequest struct {
 	PullRequestID     string                `gorm:"primaryKey;column:pull_request_id" json:"pullRequestId"`
 	PullRequestName   string                `gorm:"not null;column:pull_request_name" json:"pullRequestName"`
 	AuthorID          string                `gorm:"not null;column:author_id;index" json:"authorId"`
+	TeamName          string                `gorm:"not null;default:'';column:team_name" json:"teamName,omitempty"`
 	Status            string                `gorm:"type:pull_request_status;default:'OPEN';column:status" json:"status"`
 	CreatedAt         time.Time             `gorm:"autoCreateTime;column:created_at" json:"createdAt"`
 	MergedAt          *time.Time            `gorm:"column:merged_at" json:"mergedAt,omitempty"`
 	UpdatedAt         time.Time             `gorm:"autoUpdateTime;column:updated_at" json:"updatedAt"`
 	Author            User                  `gorm:"foreignKey:AuthorID;references:UserID" json:"-"`
 	AssignedReviewers []PullRequestReviewer `gorm:"foreignKey:PullRequestID;references:PullRequestID" json:"assignedReviewers"`
+	BusinessAge       time.Duration         `gorm:"-" json:"-"`
+}
+
+// OwnerTeam is the repository team of the PR, or the author's primary team for PRs created without one. It
+// needs Author loaded when TeamName is empty.
+func (pr *PullRequest) OwnerTeam() string {
+	if pr.TeamName != "" {
+		return pr.TeamName
+	}
+	return pr.Author.TeamName
 }
 
 type PullRequestReviewer struct {
 	PullRequestID string    `gorm:"primaryKey;column:pull_request_id;index:idx_pr_reviewer" json:"pullRequestId"`
 	UserID        string    `gorm:"primaryKey;column:user_id;index:idx_pr_reviewer" json:"userId"`
+	TeamName      string    `gorm:"not null;default:'';column:team_name" json:"teamName,omitempty"`
 	AssignedAt    time.Time `gorm:"autoCreateTime;default:CURRENT_TIMESTAMP;column:assigned_at" json:"assignedAt"`
 	User          User      `gorm:"foreignKey:UserID;references:UserID" json:"user"`
 }
@@ -38,10 +57,53 @@ type UserReview struct {
 	PullRequests []PullRequestDTO `json:"pullRequests"`
 }
 
+// AssignmentStats breaks down a member's reviews assigned on behalf of the team by PR status. OpenLoad counts
+// open reviews on behalf of any team, ReassignedAway the reviews taken from the member or withdrawn on any PR.
+// Series is filled only when the statistics are grouped by period.
 type AssignmentStats struct {
-	UserID          string `json:"userId"`
-	Username        string `json:"userName"`
-	AssignedReviews int    `json:"assignedReviews"`
+	UserID                  string `gorm:"column:user_id" json:"userId"`
+	Username                string `gorm:"column:username" json:"userName"`
+	IsActive                bool   `gorm:"column:is_active" json:"isActive"`
+	AssignedReviews         int    `gorm:"column:assigned_reviews" json:"assignedReviews"`
+	OpenReviews             int    `gorm:"column:open_reviews" json:"openReviews"`
+	MergedReviews           int    `gorm:"column:merged_reviews" json:"mergedReviews"`
+	ClosedReviews           int    `gorm:"column:closed_reviews" json:"closedReviews"`
+	OpenLoad                int    `gorm:"column:open_load" json:"openLoad"`
+	ReassignedAway          int    `gorm:"column:reassigned_away" json:"reassignedAway"`
+	AvgOpenReviewAgeMinutes int64  `gorm:"-" json:"avgOpenReviewBusinessAgeMinutes"`
+
+	Series []StatisticsBucket `gorm:"-" json:"series,omitempty"`
+}
+
+const (
+	StatisticsGroupByDay   = "day"
+	StatisticsGroupByWeek  = "week"
+	StatisticsGroupByMonth = "month"
+)
+
+// StatisticsFilter selects the window of /statistics. From is inclusive and To exclusive; GroupBy is empty
+// for plain totals.
+type StatisticsFilter struct {
+	TeamName        string
+	IncludeSubTeams bool
+	From            *time.Time
+	To              *time.Time
+	GroupBy         string
+}
+
+// StatisticsBucket is one period of a member's time series. Assignments are bucketed by assigned_at,
+// completed reviews and merges of the member's own PRs by merged_at; periods start at midnight UTC.
+type StatisticsBucket struct {
+	UserID           string    `gorm:"column:user_id" json:"-"`
+	PeriodStart      time.Time `gorm:"column:period_start" json:"periodStart"`
+	Assignments      int       `gorm:"column:assignments" json:"assignments"`
+	CompletedReviews int       `gorm:"column:completed_reviews" json:"completedReviews"`
+	Merges           int       `gorm:"column:merges" json:"merges"`
+}
+
+type OpenReviewAssignment struct {
+	UserID     string    `gorm:"column:user_id"`
+	AssignedAt time.Time `gorm:"column:assigned_at"`
 }
 
 func (Team) TableName() string {
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	NotificationChannelWebhook = "WEBHOOK"
+	NotificationChannelSlack   = "SLACK"
+	NotificationChannelEmail   = "EMAIL"
+
+	NotificationStatusPending = "PENDING"
+	NotificationStatusSent    = "SENT"
+	NotificationStatusFailed  = "FAILED"
+)
+
+type NotificationPreference struct {
+	UserID  string `gorm:"primaryKey;column:user_id" json:"-"`
+	Channel string `gorm:"primaryKey;type:notification_channel;column:channel" json:"channel"`
+	Address string `gorm:"not null;column:address" json:"address"`
+	Enabled bool   `gorm:"not null;column:enabled" json:"enabled"`
+}
+
+type Notification struct {
+	ID            int64           `gorm:"primaryKey;column:id" json:"id"`
+	EventType     string          `gorm:"not null;column:event_type" json:"eventType"`
+	RecipientID   string          `gorm:"not null;column:recipient_id" json:"recipientId"`
+	Channel       string          `gorm:"type:notification_channel;not null;column:channel" json:"channel"`
+	Address       string          `gorm:"not null;column:address" json:"address"`
+	Subject       string          `gorm:"not null;column:subject" json:"subject"`
+	Body          string          `gorm:"not null;column:body" json:"body"`
+	Payload       json.RawMessage `gorm:"type:jsonb;not null;column:payload" json:"payload"`
+	Status        string          `gorm:"type:notification_status;not null;column:status" json:"status"`
+	Attempts      int             `gorm:"not null;column:attempts" json:"attempts"`
+	NextAttemptAt time.Time       `gorm:"not null;column:next_attempt_at" json:"nextAttemptAt"`
+	LastError     string          `gorm:"not null;column:last_error" json:"lastError"`
+	CreatedAt     time.Time       `gorm:"autoCreateTime;column:created_at" json:"createdAt"`
+	SentAt        *time.Time      `gorm:"column:sent_at" json:"sentAt,omitempty"`
+}
+
+// NotificationEvent is what services hand to the outbox; it is rendered once per recipient channel.
+type NotificationEvent struct {
+	Type    string
+	Subject string
+	Text    string
+	Data    map[string]interface{}
+}
+
+type UserNotificationPreferences struct {
+	UserID   string                   `json:"userId"`
+	Channels []NotificationPreference `json:"channels"`
+}
+
+type RequestSetNotificationPreferences struct {
+	UserID   string                   `json:"userId"`
+	Channels []NotificationPreference `json:"channels"`
+}
+
+func (NotificationPreference) TableName() string {
+	return "user_notification_preferences"
+}
+
+func (Notification) TableName() string {
+	return "notification_outbox"
+}
+
+func IsValidNotificationChannel(channel string) bool {
+	switch channel {
+	case NotificationChannelWebhook, NotificationChannelSlack, NotificationChannelEmail:
+		return true
+	default:
+		return false
+	}
+}
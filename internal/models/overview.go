@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// OverviewFilter selects the window of the organisation overview; From is inclusive and To exclusive.
+type OverviewFilter struct {
+	From  *time.Time
+	To    *time.Time
+	Limit int
+}
+
+// TeamOverview counts a team's PRs: opened and merged within the window, while OpenNow and UnderstaffedPRs
+// (open PRs with fewer reviewers than are assigned on creation) describe the current state.
+type TeamOverview struct {
+	TeamName          string  `gorm:"column:team_name" json:"teamName"`
+	PRsOpened         int     `gorm:"column:prs_opened" json:"prsOpened"`
+	PRsMerged         int     `gorm:"column:prs_merged" json:"prsMerged"`
+	OpenNow           int     `gorm:"column:open_now" json:"openNow"`
+	AvgReviewersPerPR float64 `gorm:"column:avg_reviewers_per_pr" json:"avgReviewersPerPr"`
+	UnderstaffedPRs   int     `gorm:"column:understaffed_prs" json:"understaffedPrs"`
+}
+
+// LeaderboardEntry ranks a reviewer across all teams by reviews completed (PRs merged) within the window,
+// then by reviews assigned within it.
+type LeaderboardEntry struct {
+	Rank             int    `gorm:"-" json:"rank"`
+	UserID           string `gorm:"column:user_id" json:"userId"`
+	Username         string `gorm:"column:username" json:"userName"`
+	TeamName         string `gorm:"column:team_name" json:"teamName,omitempty"`
+	CompletedReviews int    `gorm:"column:completed_reviews" json:"completedReviews"`
+	AssignedReviews  int    `gorm:"column:assigned_reviews" json:"assignedReviews"`
+	OpenReviews      int    `gorm:"column:open_reviews" json:"openReviews"`
+}
+
+type OrgOverview struct {
+	From        *time.Time         `json:"from,omitempty"`
+	To          *time.Time         `json:"to,omitempty"`
+	Teams       []TeamOverview     `json:"teams"`
+	Leaderboard []LeaderboardEntry `json:"leaderboard"`
+}
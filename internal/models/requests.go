@@ -1,26 +1,46 @@
 package models
 
 type RequestCreateTeam struct {
-	TeamName string `json:"teamName"`
-	Members  []User `json:"members"`
+	TeamName   string `json:"teamName"`
+	ParentTeam string `json:"parentTeam"`
+	Members    []User `json:"members"`
 }
 
 func (r *RequestCreateTeam) ToTeam() Team {
 	return Team{
-		TeamName: r.TeamName,
-		Members:  r.Members,
+		TeamName:   r.TeamName,
+		ParentTeam: r.ParentTeam,
+		Members:    r.Members,
 	}
 }
 
+// RequestSetParentTeam moves a team under another one; an empty ParentTeam makes it a root team.
+type RequestSetParentTeam struct {
+	TeamName   string `json:"teamName"`
+	ParentTeam string `json:"parentTeam"`
+}
+
+// RequestRenameTeam changes a team's name everywhere it is referenced.
+type RequestRenameTeam struct {
+	TeamName string `json:"teamName"`
+	NewName  string `json:"newName"`
+}
+
 type RequestSetIsActive struct {
 	UserID   string `json:"userId"`
 	IsActive bool   `json:"isActive"`
 }
 
+type RequestBulkSetIsActive struct {
+	UserIDs  []string `json:"userIds"`
+	IsActive bool     `json:"isActive"`
+}
+
 type RequestCreatePR struct {
 	PullRequestID   string `json:"pullRequestId"`
 	PullRequestName string `json:"pullRequestName"`
 	AuthorID        string `json:"authorId"`
+	TeamName        string `json:"teamName"`
 }
 
 type RequestMergePR struct {
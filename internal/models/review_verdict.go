@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+const (
+	ReviewVerdictApproved         = "APPROVED"
+	ReviewVerdictChangesRequested = "CHANGES_REQUESTED"
+)
+
+// ReviewVerdict is a reviewer's decision on a PR. AssignedAt and TeamName are copied from the assignment the
+// verdict answers, so review turnaround can be measured after the reviewer is replaced or the PR is merged.
+type ReviewVerdict struct {
+	ID            int64     `gorm:"primaryKey;column:id" json:"id"`
+	PullRequestID string    `gorm:"not null;column:pull_request_id" json:"pullRequestId"`
+	UserID        string    `gorm:"not null;column:user_id" json:"reviewerId"`
+	TeamName      string    `gorm:"not null;default:'';column:team_name" json:"teamName,omitempty"`
+	Verdict       string    `gorm:"not null;column:verdict" json:"verdict"`
+	AssignedAt    time.Time `gorm:"not null;column:assigned_at" json:"assignedAt"`
+	SubmittedAt   time.Time `gorm:"not null;column:submitted_at" json:"submittedAt"`
+}
+
+type RequestSubmitReview struct {
+	PullRequestID string `json:"pullRequestId"`
+	ReviewerID    string `json:"reviewerId"`
+	Verdict       string `json:"verdict"`
+}
+
+type ResponseSubmitReview struct {
+	Review ReviewVerdict `json:"review"`
+}
+
+func (ReviewVerdict) TableName() string {
+	return "review_verdicts"
+}
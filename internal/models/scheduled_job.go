@@ -0,0 +1,62 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	ScheduledJobSetIsActive    = "user.set_is_active"
+	ScheduledJobTeamDeactivate = "team.deactivate"
+
+	ScheduledJobPending   = "PENDING"
+	ScheduledJobRunning   = "RUNNING"
+	ScheduledJobSucceeded = "SUCCEEDED"
+	ScheduledJobFailed    = "FAILED"
+	ScheduledJobCancelled = "CANCELLED"
+)
+
+// ScheduledJob is a change to run at RunAt on behalf of the actor who scheduled it. Result holds the response
+// the change would have returned from its own endpoint.
+type ScheduledJob struct {
+	ID         int64           `gorm:"primaryKey;column:id" json:"id"`
+	JobType    string          `gorm:"not null;column:job_type" json:"type"`
+	UserID     string          `gorm:"not null;default:'';column:user_id" json:"userId,omitempty"`
+	TeamName   string          `gorm:"not null;default:'';column:team_name" json:"teamName,omitempty"`
+	IsActive   bool            `gorm:"not null;column:is_active" json:"isActive"`
+	RunAt      time.Time       `gorm:"not null;column:run_at" json:"runAt"`
+	Status     string          `gorm:"type:scheduled_job_status;not null;default:'PENDING';column:status" json:"status"`
+	Actor      string          `gorm:"not null;column:actor" json:"actor"`
+	CreatedAt  time.Time       `gorm:"autoCreateTime;column:created_at" json:"createdAt"`
+	ClaimedAt  *time.Time      `gorm:"column:claimed_at" json:"claimedAt,omitempty"`
+	FinishedAt *time.Time      `gorm:"column:finished_at" json:"finishedAt,omitempty"`
+	Result     json.RawMessage `gorm:"type:jsonb;column:result" json:"result,omitempty"`
+	Error      string          `gorm:"not null;default:'';column:error" json:"error,omitempty"`
+}
+
+// RequestScheduleJob schedules a setIsActive change (userId, isActive) or a team deactivation (teamName).
+type RequestScheduleJob struct {
+	Type     string    `json:"type"`
+	UserID   string    `json:"userId"`
+	TeamName string    `json:"teamName"`
+	IsActive *bool     `json:"isActive"`
+	RunAt    time.Time `json:"runAt"`
+}
+
+type RequestCancelScheduledJob struct {
+	JobID int64 `json:"jobId"`
+}
+
+type ScheduledJobFilter struct {
+	Status  string
+	JobType string
+	Limit   int
+}
+
+type ResponseScheduledJobs struct {
+	Jobs []ScheduledJob `json:"jobs"`
+}
+
+func (ScheduledJob) TableName() string {
+	return "scheduled_jobs"
+}
@@ -0,0 +1,76 @@
+package models
+
+import "time"
+
+const (
+	SLAActionNotify      = "NOTIFY"
+	SLAActionAddReviewer = "ADD_REVIEWER"
+	SLAActionReassign    = "REASSIGN"
+)
+
+type ReviewSLA struct {
+	TeamName           string    `gorm:"primaryKey;column:team_name" json:"teamName"`
+	FirstReviewMinutes int       `gorm:"not null;column:first_review_minutes" json:"firstReviewMinutes"`
+	Action             string    `gorm:"type:sla_action;not null;column:action" json:"action"`
+	UpdatedAt          time.Time `gorm:"autoUpdateTime;column:updated_at" json:"updatedAt"`
+}
+
+type ReviewSLABreach struct {
+	ID            int64     `gorm:"primaryKey;column:id" json:"id"`
+	PullRequestID string    `gorm:"not null;column:pull_request_id" json:"pullRequestId"`
+	UserID        string    `gorm:"not null;column:user_id" json:"userId"`
+	TeamName      string    `gorm:"not null;column:team_name" json:"teamName"`
+	AssignedAt    time.Time `gorm:"not null;column:assigned_at" json:"assignedAt"`
+	DeadlineAt    time.Time `gorm:"not null;column:deadline_at" json:"deadlineAt"`
+	DetectedAt    time.Time `gorm:"not null;column:detected_at" json:"detectedAt"`
+	Action        string    `gorm:"type:sla_action;not null;column:action" json:"action"`
+	ActionResult  string    `gorm:"not null;column:action_result" json:"actionResult"`
+}
+
+type PendingReview struct {
+	PullRequestID      string    `gorm:"column:pull_request_id"`
+	UserID             string    `gorm:"column:user_id"`
+	TeamName           string    `gorm:"column:team_name"`
+	AssignedAt         time.Time `gorm:"column:assigned_at"`
+	FirstReviewMinutes int       `gorm:"column:first_review_minutes"`
+	Action             string    `gorm:"column:action"`
+}
+
+type OverdueReview struct {
+	PullRequestID   string    `gorm:"column:pull_request_id" json:"pullRequestId"`
+	PullRequestName string    `gorm:"column:pull_request_name" json:"pullRequestName"`
+	ReviewerID      string    `gorm:"column:user_id" json:"reviewerId"`
+	TeamName        string    `gorm:"column:team_name" json:"teamName"`
+	AssignedAt      time.Time `gorm:"column:assigned_at" json:"assignedAt"`
+	DeadlineAt      time.Time `gorm:"column:deadline_at" json:"deadlineAt"`
+	DetectedAt      time.Time `gorm:"column:detected_at" json:"detectedAt"`
+	Action          string    `gorm:"column:action" json:"action"`
+	ActionResult    string    `gorm:"column:action_result" json:"actionResult"`
+}
+
+type RequestSetReviewSLA struct {
+	TeamName           string `json:"teamName"`
+	FirstReviewMinutes int    `json:"firstReviewMinutes"`
+	Action             string `json:"action"`
+}
+
+type ResponseOverdueReviews struct {
+	OverdueReviews []OverdueReview `json:"overdueReviews"`
+}
+
+func (ReviewSLA) TableName() string {
+	return "team_review_slas"
+}
+
+func (ReviewSLABreach) TableName() string {
+	return "review_sla_breaches"
+}
+
+func IsValidSLAAction(action string) bool {
+	switch action {
+	case SLAActionNotify, SLAActionAddReviewer, SLAActionReassign:
+		return true
+	default:
+		return false
+	}
+}
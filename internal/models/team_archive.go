@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+const (
+	TeamArchivePolicyReassign = "reassign"
+	TeamArchivePolicyClose    = "close"
+)
+
+// RequestArchiveTeam chooses what happens to the team's open PRs: reassign keeps them open and moves their
+// reviews to eligible reviewers, close closes them.
+type RequestArchiveTeam struct {
+	TeamName string `json:"teamName"`
+	Policy   string `json:"policy"`
+}
+
+type TeamArchiveResult struct {
+	TeamName             string               `json:"teamName"`
+	Policy               string               `json:"policy"`
+	ArchivedAt           time.Time            `json:"archivedAt"`
+	DeactivatedUserIDs   []string             `json:"deactivatedUserIds"`
+	ClosedPullRequestIDs []string             `json:"closedPullRequestIds"`
+	ReassignedReviews    []ReviewReassignment `json:"reassignedReviews"`
+}
+
+// TeamDeactivationResult describes a mass deactivation. Reviewers of the team's open PRs are removed without
+// replacement, so a dry run reports exactly what the real run does without persisting it. HeldReviews, filled in
+// a dry run only, lists reviews the members hold on other PRs; they stay assigned and are not replaced.
+type TeamDeactivationResult struct {
+	TeamName               string               `json:"team"`
+	DryRun                 bool                 `json:"dryRun"`
+	DeactivationID         int64                `json:"deactivationId,omitempty"`
+	DeactivatedUserIDs     []string             `json:"deactivatedUserIds"`
+	AffectedPullRequestIDs []string             `json:"affectedPullRequestIds"`
+	RemovedReviews         []ReviewReassignment `json:"removedReviews"`
+	HeldReviews            []ReviewReassignment `json:"heldReviews"`
+}
+
+// TeamSummary is a team as shown in listings.
+type TeamSummary struct {
+	TeamName    string     `gorm:"column:team_name" json:"teamName"`
+	ParentTeam  string     `gorm:"column:parent_team" json:"parentTeam,omitempty"`
+	ArchivedAt  *time.Time `gorm:"column:archived_at" json:"archivedAt,omitempty"`
+	MemberCount int        `gorm:"column:member_count" json:"memberCount"`
+}
+
+type ResponseTeamList struct {
+	Teams []TeamSummary `json:"teams"`
+}
+
+func IsKnownTeamArchivePolicy(policy string) bool {
+	return policy == TeamArchivePolicyReassign || policy == TeamArchivePolicyClose
+}
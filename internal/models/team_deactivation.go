@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// TeamDeactivation is the snapshot of a mass team deactivation that POST /team/reactivate reverts.
+type TeamDeactivation struct {
+	ID            int64      `gorm:"primaryKey;column:id" json:"id"`
+	TeamName      string     `gorm:"not null;column:team_name" json:"teamName"`
+	Actor         string     `gorm:"not null;column:actor" json:"actor"`
+	DeactivatedAt time.Time  `gorm:"autoCreateTime;column:deactivated_at" json:"deactivatedAt"`
+	RestoredAt    *time.Time `gorm:"column:restored_at" json:"restoredAt,omitempty"`
+}
+
+type TeamDeactivationUser struct {
+	DeactivationID int64  `gorm:"primaryKey;column:deactivation_id" json:"-"`
+	UserID         string `gorm:"primaryKey;column:user_id" json:"userId"`
+}
+
+// TeamDeactivationReview is a review assignment removed by a deactivation; ReplacedBy is the reviewer who took
+// it over at the time, empty if the review was withdrawn.
+type TeamDeactivationReview struct {
+	DeactivationID int64     `gorm:"primaryKey;column:deactivation_id" json:"-"`
+	PullRequestID  string    `gorm:"primaryKey;column:pull_request_id" json:"pullRequestId"`
+	UserID         string    `gorm:"primaryKey;column:user_id" json:"userId"`
+	TeamName       string    `gorm:"not null;default:'';column:team_name" json:"teamName,omitempty"`
+	AssignedAt     time.Time `gorm:"not null;column:assigned_at" json:"assignedAt"`
+	ReplacedBy     string    `gorm:"not null;default:'';column:replaced_by" json:"replacedBy,omitempty"`
+}
+
+// TeamReactivationResult reports what a reactivation restored. A review is restored on a PR that is still open
+// when its replacement still holds it, or, for a withdrawn review, when the PR has a free reviewer slot;
+// RestoredReviews lists the replacement as OldReviewerID. Reviews that could not be restored are skipped.
+type TeamReactivationResult struct {
+	DeactivationID     int64                    `json:"deactivationId"`
+	TeamName           string                   `json:"teamName"`
+	ReactivatedUserIDs []string                 `json:"reactivatedUserIds"`
+	RestoredReviews    []ReviewReassignment     `json:"restoredReviews"`
+	SkippedReviews     []TeamDeactivationReview `json:"skippedReviews"`
+}
+
+func (TeamDeactivation) TableName() string {
+	return "team_deactivations"
+}
+
+func (TeamDeactivationUser) TableName() string {
+	return "team_deactivation_users"
+}
+
+func (TeamDeactivationReview) TableName() string {
+	return "team_deactivation_reviews"
+}
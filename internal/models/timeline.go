@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+const (
+	PullRequestEventCreated            = "created"
+	PullRequestEventReviewerAssigned   = "reviewer_assigned"
+	PullRequestEventReviewerReassigned = "reviewer_reassigned"
+	PullRequestEventReviewerRemoved    = "reviewer_removed"
+	PullRequestEventMerged             = "merged"
+	PullRequestEventClosed             = "closed"
+	PullRequestEventReopened           = "reopened"
+	// PullRequestEventReviewSubmitted carries the reviewer in NewReviewerID and the verdict in Reason.
+	PullRequestEventReviewSubmitted = "review_submitted"
+
+	ReviewerChangeInitial             = "initial"
+	ReviewerChangeManual              = "manual"
+	ReviewerChangeSLAEscalation       = "sla_escalation"
+	ReviewerChangeReviewerUnavailable = "reviewer_unavailable"
+	ReviewerChangeTeamDeactivated     = "team_deactivated"
+	ReviewerChangeTeamReactivated     = "team_reactivated"
+)
+
+// PullRequestEvent is one entry of the append-only PR timeline. Reviewer IDs are plain values rather than
+// foreign keys so the history outlives the assignment rows it describes.
+type PullRequestEvent struct {
+	ID            int64     `gorm:"primaryKey;column:id" json:"id"`
+	PullRequestID string    `gorm:"not null;column:pull_request_id" json:"pullRequestId"`
+	EventType     string    `gorm:"not null;column:event_type" json:"type"`
+	OldReviewerID string    `gorm:"not null;column:old_reviewer_id" json:"oldReviewerId,omitempty"`
+	NewReviewerID string    `gorm:"not null;column:new_reviewer_id" json:"newReviewerId,omitempty"`
+	Reason        string    `gorm:"not null;column:reason" json:"reason,omitempty"`
+	Actor         string    `gorm:"not null;column:actor" json:"actor"`
+	OccurredAt    time.Time `gorm:"not null;column:occurred_at" json:"occurredAt"`
+}
+
+type ResponsePullRequestHistory struct {
+	PullRequestID string             `json:"pullRequestId"`
+	Status        string             `json:"status"`
+	Events        []PullRequestEvent `json:"events"`
+}
+
+func (PullRequestEvent) TableName() string {
+	return "pull_request_events"
+}
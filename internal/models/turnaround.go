@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// DurationPercentiles are nearest-rank percentiles of a set of durations in business minutes of the team's
+// calendar; all of them are zero when Count is zero.
+type DurationPercentiles struct {
+	Count      int   `json:"count"`
+	P50Minutes int64 `json:"p50BusinessMinutes"`
+	P90Minutes int64 `json:"p90BusinessMinutes"`
+	P99Minutes int64 `json:"p99BusinessMinutes"`
+}
+
+// TeamTurnaround covers the window [From, To). Cycle time runs from creation to merge of PRs merged within the
+// window; review turnaround runs from a review assignment to the reviewer's first verdict on it, for first
+// verdicts submitted within the window.
+type TeamTurnaround struct {
+	TeamName         string               `json:"teamName"`
+	From             *time.Time           `json:"from,omitempty"`
+	To               *time.Time           `json:"to,omitempty"`
+	CycleTime        DurationPercentiles  `json:"cycleTime"`
+	ReviewTurnaround DurationPercentiles  `json:"reviewTurnaround"`
+	Reviewers        []ReviewerTurnaround `json:"reviewers"`
+}
+
+// ReviewerTurnaround is the review turnaround of one reviewer's assignments and the cycle time of the PRs
+// merged within the window that they were assigned to at merge.
+type ReviewerTurnaround struct {
+	UserID           string              `json:"userId"`
+	Username         string              `json:"username"`
+	ReviewTurnaround DurationPercentiles `json:"reviewTurnaround"`
+	CycleTime        DurationPercentiles `json:"cycleTime"`
+}
+
+type ReviewTurnaroundSample struct {
+	UserID      string    `gorm:"column:user_id"`
+	Username    string    `gorm:"column:username"`
+	AssignedAt  time.Time `gorm:"column:assigned_at"`
+	SubmittedAt time.Time `gorm:"column:submitted_at"`
+}
+
+type ReviewerCycleTimeSample struct {
+	UserID    string    `gorm:"column:user_id"`
+	Username  string    `gorm:"column:username"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	MergedAt  time.Time `gorm:"column:merged_at"`
+}
+
+type CycleTimeSample struct {
+	PullRequestID string    `gorm:"column:pull_request_id"`
+	CreatedAt     time.Time `gorm:"column:created_at"`
+	MergedAt      time.Time `gorm:"column:merged_at"`
+}
@@ -0,0 +1,31 @@
+package models
+
+// RequestUpdateUser changes only the fields that are present; an empty string clears an optional field.
+type RequestUpdateUser struct {
+	UserID      string  `json:"userId"`
+	Username    *string `json:"username"`
+	Email       *string `json:"email"`
+	DisplayName *string `json:"displayName"`
+	ChatHandle  *string `json:"chatHandle"`
+	Timezone    *string `json:"timezone"`
+}
+
+// UserFilter selects users for listing; TeamName matches any membership, not only the primary team.
+type UserFilter struct {
+	TeamName string
+	IsActive *bool
+	Limit    int
+	Offset   int
+}
+
+type ResponseUser struct {
+	User  User     `json:"user"`
+	Teams []string `json:"teams"`
+}
+
+type ResponseUserList struct {
+	Users  []User `json:"users"`
+	Total  int64  `json:"total"`
+	Limit  int    `json:"limit"`
+	Offset int    `json:"offset"`
+}
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	WebhookDeliveryPending   = "PENDING"
+	WebhookDeliveryDelivered = "DELIVERED"
+	WebhookDeliveryFailed    = "FAILED"
+)
+
+type WebhookSubscription struct {
+	ID        int64     `gorm:"primaryKey;column:id" json:"id"`
+	URL       string    `gorm:"not null;column:url" json:"url"`
+	Secret    string    `gorm:"not null;column:secret" json:"-"`
+	Events    string    `gorm:"not null;column:events" json:"-"`
+	IsActive  bool      `gorm:"not null;column:is_active" json:"isActive"`
+	CreatedAt time.Time `gorm:"autoCreateTime;column:created_at" json:"createdAt"`
+}
+
+type WebhookDelivery struct {
+	ID             int64                    `gorm:"primaryKey;column:id" json:"id"`
+	SubscriptionID int64                    `gorm:"not null;column:subscription_id" json:"subscriptionId"`
+	EventType      string                   `gorm:"not null;column:event_type" json:"eventType"`
+	Payload        json.RawMessage          `gorm:"type:jsonb;not null;column:payload" json:"payload"`
+	Status         string                   `gorm:"type:webhook_delivery_status;not null;column:status" json:"status"`
+	Attempts       int                      `gorm:"not null;column:attempts" json:"attempts"`
+	NextAttemptAt  time.Time                `gorm:"not null;column:next_attempt_at" json:"nextAttemptAt"`
+	CreatedAt      time.Time                `gorm:"autoCreateTime;column:created_at" json:"createdAt"`
+	DeliveredAt    *time.Time               `gorm:"column:delivered_at" json:"deliveredAt,omitempty"`
+	Subscription   WebhookSubscription      `gorm:"foreignKey:SubscriptionID;references:ID" json:"-"`
+	AttemptLog     []WebhookDeliveryAttempt `gorm:"foreignKey:DeliveryID;references:ID" json:"attemptLog,omitempty"`
+}
+
+type WebhookDeliveryAttempt struct {
+	ID             int64     `gorm:"primaryKey;column:id" json:"id"`
+	DeliveryID     int64     `gorm:"not null;column:delivery_id" json:"deliveryId"`
+	AttemptedAt    time.Time `gorm:"not null;column:attempted_at" json:"attemptedAt"`
+	ResponseStatus *int      `gorm:"column:response_status" json:"responseStatus,omitempty"`
+	Error          string    `gorm:"not null;column:error" json:"error,omitempty"`
+	DurationMs     int64     `gorm:"not null;column:duration_ms" json:"durationMs"`
+}
+
+type WebhookSubscriptionDTO struct {
+	ID        int64     `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	IsActive  bool      `json:"isActive"`
+	CreatedAt time.Time `json:"createdAt"`
+	Secret    string    `json:"secret,omitempty"`
+}
+
+type WebhookDeliveryFilter struct {
+	SubscriptionID int64
+	EventType      string
+	Status         string
+	Limit          int
+}
+
+type RequestCreateWebhook struct {
+	URL    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events"`
+}
+
+type RequestDeleteWebhook struct {
+	SubscriptionID int64 `json:"subscriptionId"`
+}
+
+// RequestRedeliverWebhook redelivers a FAILED delivery; a DELIVERED one is sent again only with Force.
+type RequestRedeliverWebhook struct {
+	DeliveryID int64 `json:"deliveryId"`
+	Force      bool  `json:"force,omitempty"`
+}
+
+type ResponseWebhookSubscriptions struct {
+	Subscriptions []WebhookSubscriptionDTO `json:"subscriptions"`
+}
+
+type ResponseWebhookDeliveries struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+}
+
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+func (WebhookDeliveryAttempt) TableName() string {
+	return "webhook_delivery_attempts"
+}
@@ -0,0 +1,46 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+var ErrNoSender = errors.New("no sender registered for channel")
+
+type Message struct {
+	EventType string
+	Subject   string
+	Text      string
+	Payload   json.RawMessage
+}
+
+type Sender interface {
+	Channel() string
+	Send(ctx context.Context, address string, message Message) error
+}
+
+type Registry struct {
+	senders map[string]Sender
+}
+
+func NewRegistry(senders ...Sender) *Registry {
+	registry := &Registry{
+		senders: make(map[string]Sender, len(senders)),
+	}
+
+	for _, sender := range senders {
+		registry.senders[sender.Channel()] = sender
+	}
+
+	return registry
+}
+
+func (r *Registry) Send(ctx context.Context, channel, address string, message Message) error {
+	sender, ok := r.senders[channel]
+	if !ok {
+		return ErrNoSender
+	}
+
+	return sender.Send(ctx, address, message)
+}
@@ -0,0 +1,128 @@
+package notifications
+
+import (
+	"CodeRewievService/internal/models"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultSMTPTimeout = 10 * time.Second
+
+// SMTPConfig configures the SMTP sender. TLSConfig is used for STARTTLS; when nil the server certificate is
+// verified against the system roots for Host.
+type SMTPConfig struct {
+	Host      string
+	Port      int
+	From      string
+	Username  string
+	Password  string
+	TLSConfig *tls.Config
+}
+
+type SMTPSender struct {
+	config SMTPConfig
+}
+
+func NewSMTPSender(config SMTPConfig) *SMTPSender {
+	return &SMTPSender{config: config}
+}
+
+func (s *SMTPSender) Channel() string {
+	return models.NotificationChannelEmail
+}
+
+func (s *SMTPSender) Send(ctx context.Context, address string, message Message) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultSMTPTimeout)
+	defer cancel()
+
+	dialer := net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(s.config.Host, strconv.Itoa(s.config.Port)))
+	if err != nil {
+		return fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, s.config.Host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("failed to start smtp session: %w", err)
+	}
+	defer client.Close()
+
+	if err := s.authenticate(client); err != nil {
+		return err
+	}
+
+	if err := client.Mail(s.config.From); err != nil {
+		return fmt.Errorf("smtp MAIL FROM failed: %w", err)
+	}
+	if err := client.Rcpt(address); err != nil {
+		return fmt.Errorf("smtp RCPT TO failed: %w", err)
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp DATA failed: %w", err)
+	}
+
+	if _, err := writer.Write(s.buildMessage(address, message)); err != nil {
+		_ = writer.Close()
+		return fmt.Errorf("failed to write smtp message: %w", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to finish smtp message: %w", err)
+	}
+
+	return client.Quit()
+}
+
+func (s *SMTPSender) authenticate(client *smtp.Client) error {
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		tlsConfig := s.config.TLSConfig
+		if tlsConfig == nil {
+			//nolint:gosec // версия TLS определяется сервером
+			tlsConfig = &tls.Config{ServerName: s.config.Host}
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("smtp STARTTLS failed: %w", err)
+		}
+	}
+
+	if s.config.Username == "" {
+		return nil
+	}
+
+	if err := client.Auth(smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)); err != nil {
+		return fmt.Errorf("smtp authentication failed: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SMTPSender) buildMessage(address string, message Message) []byte {
+	var builder strings.Builder
+	builder.WriteString("From: " + s.config.From + "\r\n")
+	builder.WriteString("To: " + address + "\r\n")
+	builder.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", sanitizeHeader(message.Subject)) + "\r\n")
+	builder.WriteString("MIME-Version: 1.0\r\n")
+	builder.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	builder.WriteString("\r\n")
+	builder.WriteString(strings.ReplaceAll(message.Text, "\n", "\r\n"))
+	builder.WriteString("\r\n")
+	return []byte(builder.String())
+}
+
+func sanitizeHeader(value string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+}
@@ -0,0 +1,268 @@
+package notifications
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math/big"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeSMTPServer accepts one SMTP session and records what the client sent.
+type fakeSMTPServer struct {
+	listener   net.Listener
+	tlsConfig  *tls.Config
+	startTLS   bool
+	rejectAuth bool
+
+	done     chan struct{}
+	usedTLS  bool
+	auth     string
+	mailFrom string
+	rcptTo   string
+	data     string
+}
+
+func newFakeSMTPServer(t *testing.T, tlsConfig *tls.Config, startTLS, rejectAuth bool) *fakeSMTPServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	server := &fakeSMTPServer{
+		listener:   listener,
+		tlsConfig:  tlsConfig,
+		startTLS:   startTLS,
+		rejectAuth: rejectAuth,
+		done:       make(chan struct{}),
+	}
+	go server.serve()
+	t.Cleanup(func() { _ = listener.Close() })
+	return server
+}
+
+func (s *fakeSMTPServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTPServer) serve() {
+	defer close(s.done)
+
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	text := textproto.NewConn(conn)
+	_ = text.PrintfLine("220 fake ESMTP")
+
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			switch {
+			case s.startTLS && !s.usedTLS:
+				_ = text.PrintfLine("250-fake\r\n250 STARTTLS")
+			default:
+				_ = text.PrintfLine("250-fake\r\n250 AUTH PLAIN")
+			}
+		case "STARTTLS":
+			_ = text.PrintfLine("220 ready to start TLS")
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn = tlsConn
+			text = textproto.NewConn(conn)
+			s.usedTLS = true
+		case "AUTH":
+			s.auth = arg
+			if s.rejectAuth {
+				_ = text.PrintfLine("535 authentication failed")
+				continue
+			}
+			_ = text.PrintfLine("235 authenticated")
+		case "MAIL":
+			s.mailFrom = arg
+			_ = text.PrintfLine("250 ok")
+		case "RCPT":
+			s.rcptTo = arg
+			_ = text.PrintfLine("250 ok")
+		case "DATA":
+			_ = text.PrintfLine("354 go ahead")
+			lines, err := text.ReadDotLines()
+			if err != nil {
+				return
+			}
+			s.data = strings.Join(lines, "\n")
+			_ = text.PrintfLine("250 queued")
+		case "QUIT":
+			_ = text.PrintfLine("221 bye")
+			return
+		default:
+			_ = text.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+// selfSignedTLS returns a server config with a certificate for 127.0.0.1 and a client config trusting it.
+func selfSignedTLS(t *testing.T) (*tls.Config, *tls.Config) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "fake smtp"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parse certificate: %v", err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(certificate)
+
+	server := &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+		MinVersion:   tls.VersionTLS12,
+	}
+	client := &tls.Config{ServerName: "127.0.0.1", RootCAs: roots, MinVersion: tls.VersionTLS12}
+	return server, client
+}
+
+func TestSMTPSenderSend(t *testing.T) {
+	serverTLS, clientTLS := selfSignedTLS(t)
+	message := Message{
+		EventType: "review.requested",
+		Subject:   "Ревью PR-1001",
+		Text:      "Please review\nPR-1001",
+	}
+
+	tests := []struct {
+		name       string
+		startTLS   bool
+		username   string
+		rejectAuth bool
+		wantErr    string
+		wantTLS    bool
+		wantAuth   string
+	}{
+		{
+			name:     "STARTTLS and PLAIN authentication",
+			startTLS: true,
+			username: "bot",
+			wantTLS:  true,
+			wantAuth: "PLAIN " + base64.StdEncoding.EncodeToString([]byte("\x00bot\x00secret")),
+		},
+		{
+			name: "no TLS and no credentials",
+		},
+		{
+			name:       "rejected credentials",
+			startTLS:   true,
+			username:   "bot",
+			rejectAuth: true,
+			wantErr:    "smtp authentication failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeSMTPServer(t, serverTLS, tt.startTLS, tt.rejectAuth)
+			sender := NewSMTPSender(SMTPConfig{
+				Host:      "127.0.0.1",
+				Port:      server.port(),
+				From:      "reviewer-bot@example.com",
+				Username:  tt.username,
+				Password:  "secret",
+				TLSConfig: clientTLS,
+			})
+
+			err := sender.Send(context.Background(), "alice@example.com", message)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Send() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Send() error = %v", err)
+			}
+			<-server.done
+
+			if server.usedTLS != tt.wantTLS {
+				t.Fatalf("TLS used = %v, want %v", server.usedTLS, tt.wantTLS)
+			}
+			if server.auth != tt.wantAuth {
+				t.Fatalf("AUTH = %q, want %q", server.auth, tt.wantAuth)
+			}
+			if server.mailFrom != "FROM:<reviewer-bot@example.com>" || server.rcptTo != "TO:<alice@example.com>" {
+				t.Fatalf("envelope = %q / %q", server.mailFrom, server.rcptTo)
+			}
+
+			for _, want := range []string{
+				"From: reviewer-bot@example.com",
+				"To: alice@example.com",
+				"Subject: =?utf-8?q?=D0=A0=D0=B5=D0=B2=D1=8C=D1=8E_PR-1001?=",
+				"Content-Type: text/plain; charset=UTF-8",
+				"\n\nPlease review\nPR-1001",
+			} {
+				if !strings.Contains(server.data, want) {
+					t.Fatalf("message does not contain %q:\n%s", want, server.data)
+				}
+			}
+		})
+	}
+}
+
+func TestSMTPSenderBuildMessage(t *testing.T) {
+	sender := NewSMTPSender(SMTPConfig{From: "bot@example.com"})
+
+	tests := []struct {
+		name        string
+		subject     string
+		wantSubject string
+	}{
+		{"ASCII subject is kept", "PR-1001 merged", "Subject: PR-1001 merged\r\n"},
+		{"non-ASCII subject is encoded", "Ревью", "Subject: =?utf-8?q?=D0=A0=D0=B5=D0=B2=D1=8C=D1=8E?=\r\n"},
+		{"header injection is neutralised", "hi\r\nBcc: eve@example.com", "Subject: hi  Bcc: eve@example.com\r\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := string(sender.buildMessage("alice@example.com", Message{Subject: tt.subject}))
+			if !strings.Contains(message, tt.wantSubject) {
+				t.Fatalf("message does not contain %q:\n%s", tt.wantSubject, message)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package notifications
+
+import (
+	"CodeRewievService/internal/models"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const defaultHTTPTimeout = 10 * time.Second
+
+type webhookPayload struct {
+	Event   string          `json:"event"`
+	Subject string          `json:"subject"`
+	Text    string          `json:"text"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+type slackPayload struct {
+	Text string `json:"text"`
+}
+
+// WebhookSender posts the full notification as JSON to a user-provided URL.
+type WebhookSender struct {
+	client *http.Client
+}
+
+func NewWebhookSender(client *http.Client) *WebhookSender {
+	return &WebhookSender{client: httpClientOrDefault(client)}
+}
+
+func (s *WebhookSender) Channel() string {
+	return models.NotificationChannelWebhook
+}
+
+func (s *WebhookSender) Send(ctx context.Context, address string, message Message) error {
+	return postJSON(ctx, s.client, address, webhookPayload{
+		Event:   message.EventType,
+		Subject: message.Subject,
+		Text:    message.Text,
+		Data:    message.Payload,
+	})
+}
+
+// SlackSender posts to a Slack-compatible incoming webhook, which only understands a text field.
+type SlackSender struct {
+	client *http.Client
+}
+
+func NewSlackSender(client *http.Client) *SlackSender {
+	return &SlackSender{client: httpClientOrDefault(client)}
+}
+
+func (s *SlackSender) Channel() string {
+	return models.NotificationChannelSlack
+}
+
+func (s *SlackSender) Send(ctx context.Context, address string, message Message) error {
+	return postJSON(ctx, s.client, address, slackPayload{
+		Text: fmt.Sprintf("*%s*\n%s", message.Subject, message.Text),
+	})
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func httpClientOrDefault(client *http.Client) *http.Client {
+	if client != nil {
+		return client
+	}
+	return &http.Client{Timeout: defaultHTTPTimeout}
+}
@@ -0,0 +1,69 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPSenders(t *testing.T) {
+	message := Message{
+		EventType: "review.requested",
+		Subject:   "Review PR-1001",
+		Text:      "Please review",
+		Payload:   json.RawMessage(`{"pullRequestId":"PR-1001"}`),
+	}
+
+	tests := []struct {
+		name     string
+		sender   Sender
+		status   int
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name:     "webhook posts the full notification",
+			sender:   NewWebhookSender(nil),
+			status:   http.StatusNoContent,
+			wantBody: `{"event":"review.requested","subject":"Review PR-1001","text":"Please review","data":{"pullRequestId":"PR-1001"}}`,
+		},
+		{
+			name:     "slack posts formatted text only",
+			sender:   NewSlackSender(nil),
+			status:   http.StatusOK,
+			wantBody: `{"text":"*Review PR-1001*\nPlease review"}`,
+		},
+		{
+			name:    "error status fails the send",
+			sender:  NewWebhookSender(nil),
+			status:  http.StatusBadGateway,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Content-Type"); got != "application/json" {
+					t.Errorf("Content-Type = %q, want application/json", got)
+				}
+				received, _ := io.ReadAll(r.Body)
+				body = string(received)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			err := tt.sender.Send(context.Background(), server.URL, message)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Send() error = %v, want error %v", err, tt.wantErr)
+			}
+			if tt.wantBody != "" && body != tt.wantBody {
+				t.Fatalf("body = %s, want %s", body, tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"CodeRewievService/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type AuditRepository struct {
+	database *gorm.DB
+}
+
+func NewAuditRepository(database *gorm.DB) *AuditRepository {
+	return &AuditRepository{
+		database: database,
+	}
+}
+
+func (r *AuditRepository) CreateInTx(tx *gorm.DB, record *models.AuditRecord) error {
+	return tx.Create(record).Error
+}
+
+func (r *AuditRepository) CreateManyInTx(tx *gorm.DB, records []models.AuditRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	return tx.Create(&records).Error
+}
+
+func (r *AuditRepository) List(filter models.AuditFilter) ([]models.AuditRecord, error) {
+	query := r.database.Model(&models.AuditRecord{})
+
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.Operation != "" {
+		query = query.Where("operation = ?", filter.Operation)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	var records []models.AuditRecord
+	err := query.Order("created_at DESC, id DESC").Limit(filter.Limit).Find(&records).Error
+	return records, err
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"CodeRewievService/internal/models"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CalendarRepository struct {
+	database *gorm.DB
+}
+
+func NewCalendarRepository(database *gorm.DB) *CalendarRepository {
+	return &CalendarRepository{
+		database: database,
+	}
+}
+
+func (r *CalendarRepository) FindByTeam(teamName string) (*models.TeamCalendar, error) {
+	var teamCalendar models.TeamCalendar
+	result := r.database.Where("team_name = ?", teamName).First(&teamCalendar)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &teamCalendar, nil
+}
+
+func (r *CalendarRepository) Upsert(teamCalendar *models.TeamCalendar) error {
+	return r.database.
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "team_name"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"timezone", "work_days", "work_start_minute", "work_end_minute", "updated_at",
+			}),
+		}).
+		Create(teamCalendar).Error
+}
+
+func (r *CalendarRepository) GetHolidays(teamName string) ([]models.TeamHoliday, error) {
+	var holidays []models.TeamHoliday
+	err := r.database.
+		Where("team_name = ?", teamName).
+		Order("holiday_date").
+		Find(&holidays).Error
+
+	return holidays, err
+}
+
+func (r *CalendarRepository) SaveHolidays(teamName string, holidays []models.TeamHoliday, replace bool) error {
+	return r.database.Transaction(func(tx *gorm.DB) error {
+		if replace {
+			if err := tx.Where("team_name = ?", teamName).Delete(&models.TeamHoliday{}).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(holidays) == 0 {
+			return nil
+		}
+
+		return tx.
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "team_name"}, {Name: "holiday_date"}},
+				DoUpdates: clause.AssignmentColumns([]string{"name"}),
+			}).
+			Create(&holidays).Error
+	})
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"CodeRewievService/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IntegrationRepository struct {
+	database *gorm.DB
+}
+
+func NewIntegrationRepository(database *gorm.DB) *IntegrationRepository {
+	return &IntegrationRepository{
+		database: database,
+	}
+}
+
+// ClaimDelivery records an inbound delivery and reports false when it was already seen.
+func (r *IntegrationRepository) ClaimDelivery(delivery *models.IntegrationDelivery) (bool, error) {
+	result := r.database.Clauses(clause.OnConflict{DoNothing: true}).Create(delivery)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *IntegrationRepository) CompleteDelivery(provider, deliveryID, result string) error {
+	return r.database.Model(&models.IntegrationDelivery{}).
+		Where("provider = ? AND delivery_id = ?", provider, deliveryID).
+		Update("result", result).Error
+}
+
+// ReleaseDelivery forgets a delivery whose processing failed, so the provider's retry is handled again.
+func (r *IntegrationRepository) ReleaseDelivery(provider, deliveryID string) error {
+	return r.database.
+		Where("provider = ? AND delivery_id = ?", provider, deliveryID).
+		Delete(&models.IntegrationDelivery{}).Error
+}
+
+func (r *IntegrationRepository) FindUserIDByLogin(provider, login string) (string, error) {
+	var mapping models.ExternalUserMapping
+	err := r.database.
+		Where("provider = ? AND external_login = ?", provider, login).
+		First(&mapping).Error
+
+	return mapping.UserID, err
+}
+
+// FindLoginsByUserIDs returns the external logins of the given users, keyed by user ID.
+func (r *IntegrationRepository) FindLoginsByUserIDs(provider string, userIDs []string) (map[string]string, error) {
+	logins := make(map[string]string, len(userIDs))
+	if len(userIDs) == 0 {
+		return logins, nil
+	}
+
+	var mappings []models.ExternalUserMapping
+	err := r.database.
+		Where("provider = ? AND user_id IN ?", provider, userIDs).
+		Order("external_login").
+		Find(&mappings).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mapping := range mappings {
+		if _, ok := logins[mapping.UserID]; !ok {
+			logins[mapping.UserID] = mapping.ExternalLogin
+		}
+	}
+
+	return logins, nil
+}
+
+func (r *IntegrationRepository) UpsertUserMapping(mapping *models.ExternalUserMapping) error {
+	return r.database.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "provider"}, {Name: "external_login"}},
+		DoUpdates: clause.AssignmentColumns([]string{"user_id"}),
+	}).Create(mapping).Error
+}
+
+func (r *IntegrationRepository) ListUserMappings(provider string) ([]models.ExternalUserMapping, error) {
+	var mappings []models.ExternalUserMapping
+	query := r.database.Order("provider, external_login")
+	if provider != "" {
+		query = query.Where("provider = ?", provider)
+	}
+
+	err := query.Find(&mappings).Error
+	return mappings, err
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"CodeRewievService/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepository struct {
+	database *gorm.DB
+}
+
+func NewNotificationRepository(database *gorm.DB) *NotificationRepository {
+	return &NotificationRepository{
+		database: database,
+	}
+}
+
+func (r *NotificationRepository) GetPreferences(userID string) ([]models.NotificationPreference, error) {
+	var preferences []models.NotificationPreference
+	err := r.database.
+		Where("user_id = ?", userID).
+		Order("channel").
+		Find(&preferences).Error
+
+	return preferences, err
+}
+
+func (r *NotificationRepository) ReplacePreferences(userID string, preferences []models.NotificationPreference) error {
+	return r.database.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.NotificationPreference{}).Error; err != nil {
+			return err
+		}
+
+		if len(preferences) == 0 {
+			return nil
+		}
+
+		return tx.Create(&preferences).Error
+	})
+}
+
+func (r *NotificationRepository) GetEnabledPreferencesInTx(
+	tx *gorm.DB,
+	userIDs []string,
+) ([]models.NotificationPreference, error) {
+	var preferences []models.NotificationPreference
+	err := tx.
+		Where("user_id IN (?) AND enabled = ?", userIDs, true).
+		Find(&preferences).Error
+
+	return preferences, err
+}
+
+func (r *NotificationRepository) EnqueueInTx(tx *gorm.DB, notifications []models.Notification) error {
+	return tx.Create(&notifications).Error
+}
+
+// LeasePending claims due notifications by moving their next attempt lease into the future, so concurrent
+// dispatchers skip them while they are being sent and pick them up again if the dispatcher dies.
+func (r *NotificationRepository) LeasePending(now time.Time, lease time.Duration, limit int) ([]models.Notification, error) {
+	var notifications []models.Notification
+	err := r.database.Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.NotificationStatusPending, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&notifications).Error
+		if err != nil || len(notifications) == 0 {
+			return err
+		}
+
+		ids := make([]int64, 0, len(notifications))
+		for _, notification := range notifications {
+			ids = append(ids, notification.ID)
+		}
+
+		return tx.Model(&models.Notification{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+
+	return notifications, err
+}
+
+func (r *NotificationRepository) UpdateDelivery(notification *models.Notification) error {
+	return r.database.Model(&models.Notification{}).
+		Where("id = ?", notification.ID).
+		Updates(map[string]interface{}{
+			"status":          notification.Status,
+			"attempts":        notification.Attempts,
+			"next_attempt_at": notification.NextAttemptAt,
+			"last_error":      notification.LastError,
+			"sent_at":         notification.SentAt,
+		}).Error
+}
+
+func (r *NotificationRepository) Transaction(fn func(*gorm.DB) error) error {
+	return r.database.Transaction(fn)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PullRequestRepository struct {
@@ -50,8 +51,12 @@ func (r *PullRequestRepository) FindByIDWithRelations(prID string) (*models.Pull
 }
 
 func (r *PullRequestRepository) FindByIDWithReviewers(prID string) (*models.PullRequest, error) {
+	return r.FindByIDWithReviewersInTx(r.database, prID)
+}
+
+func (r *PullRequestRepository) FindByIDWithReviewersInTx(tx *gorm.DB, prID string) (*models.PullRequest, error) {
 	var pr models.PullRequest
-	result := r.database.
+	result := tx.
 		Preload("AssignedReviewers").
 		Where("pull_request_id = ?", prID).
 		First(&pr)
@@ -70,30 +75,142 @@ func (r *PullRequestRepository) Create(pr *models.PullRequest) error {
 	return r.database.Create(pr).Error
 }
 
+func (r *PullRequestRepository) CreateInTx(tx *gorm.DB, pr *models.PullRequest) error {
+	return tx.Create(pr).Error
+}
+
 func (r *PullRequestRepository) Update(pr *models.PullRequest) error {
 	return r.database.Save(pr).Error
 }
 
+func (r *PullRequestRepository) UpdateInTx(tx *gorm.DB, pr *models.PullRequest) error {
+	return tx.Omit(clause.Associations).Save(pr).Error
+}
+
 func (r *PullRequestRepository) DeleteReviewer(prID string, userID string) error {
 	return r.database.
 		Where("pull_request_id = ? AND user_id = ?", prID, userID).
 		Delete(&models.PullRequestReviewer{}).Error
 }
 
+func (r *PullRequestRepository) DeleteReviewerInTx(tx *gorm.DB, prID string, userID string) error {
+	return tx.
+		Where("pull_request_id = ? AND user_id = ?", prID, userID).
+		Delete(&models.PullRequestReviewer{}).Error
+}
+
 func (r *PullRequestRepository) CreateReviewer(reviewer *models.PullRequestReviewer) error {
 	return r.database.Create(reviewer).Error
 }
 
+func (r *PullRequestRepository) CreateReviewerInTx(tx *gorm.DB, reviewer *models.PullRequestReviewer) error {
+	return tx.Omit("User").Create(reviewer).Error
+}
+
 func (r *PullRequestRepository) DeleteReviewersByPRIDs(prIDs []string) error {
 	return r.database.
 		Where("pull_request_id IN (?)", prIDs).
 		Delete(&models.PullRequestReviewer{}).Error
 }
 
-func (r *PullRequestRepository) DeleteReviewersByPRIDsInTx(tx *gorm.DB, prIDs []string) error {
-	return tx.
+// DeleteReviewersByPRIDsInTx removes every reviewer of the given PRs and returns the removed assignments.
+func (r *PullRequestRepository) DeleteReviewersByPRIDsInTx(tx *gorm.DB, prIDs []string) ([]models.PullRequestReviewer, error) {
+	var removed []models.PullRequestReviewer
+	err := tx.
+		Clauses(clause.Returning{}).
 		Where("pull_request_id IN (?)", prIDs).
-		Delete(&models.PullRequestReviewer{}).Error
+		Delete(&removed).Error
+
+	return removed, err
+}
+
+func (r *PullRequestRepository) CreateVerdictInTx(tx *gorm.DB, verdict *models.ReviewVerdict) error {
+	return tx.Create(verdict).Error
+}
+
+func (r *PullRequestRepository) CreateEventsInTx(tx *gorm.DB, events []models.PullRequestEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	return tx.Create(&events).Error
+}
+
+func (r *PullRequestRepository) GetEvents(prID string) ([]models.PullRequestEvent, error) {
+	var events []models.PullRequestEvent
+	err := r.database.
+		Where("pull_request_id = ?", prID).
+		Order("occurred_at, id").
+		Find(&events).Error
+
+	return events, err
+}
+
+func (r *PullRequestRepository) GetOpenPRsReviewedByInTx(tx *gorm.DB, userIDs []string) ([]models.PullRequest, error) {
+	var pullRequests []models.PullRequest
+	if len(userIDs) == 0 {
+		return pullRequests, nil
+	}
+
+	err := tx.
+		Preload("AssignedReviewers.User").
+		Preload("Author").
+		Where("status = ?", "OPEN").
+		Where("pull_request_id IN (?)",
+			tx.Model(&models.PullRequestReviewer{}).Select("pull_request_id").Where("user_id IN ?", userIDs)).
+		Order("pull_request_id").
+		Find(&pullRequests).Error
+
+	return pullRequests, err
+}
+
+// FindOpenReviewsByReviewersInTx returns the users' review assignments on open PRs.
+func (r *PullRequestRepository) FindOpenReviewsByReviewersInTx(
+	tx *gorm.DB,
+	userIDs []string,
+) ([]models.PullRequestReviewer, error) {
+	var reviewers []models.PullRequestReviewer
+	if len(userIDs) == 0 {
+		return reviewers, nil
+	}
+
+	err := tx.
+		Joins("JOIN pull_requests pr ON pr.pull_request_id = pull_request_reviewers.pull_request_id").
+		Where("pr.status = ? AND pull_request_reviewers.user_id IN ?", "OPEN", userIDs).
+		Order("pull_request_reviewers.pull_request_id, pull_request_reviewers.user_id").
+		Find(&reviewers).Error
+
+	return reviewers, err
+}
+
+// FindOpenByIDsForUpdateInTx locks the open PRs among prIDs and loads their reviewers.
+func (r *PullRequestRepository) FindOpenByIDsForUpdateInTx(tx *gorm.DB, prIDs []string) ([]models.PullRequest, error) {
+	var pullRequests []models.PullRequest
+	if len(prIDs) == 0 {
+		return pullRequests, nil
+	}
+
+	err := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("AssignedReviewers").
+		Preload("Author").
+		Where("status = ? AND pull_request_id IN ?", "OPEN", prIDs).
+		Order("pull_request_id").
+		Find(&pullRequests).Error
+
+	return pullRequests, err
+}
+
+func (r *PullRequestRepository) GetOpenPRsAuthoredByInTx(tx *gorm.DB, authorID string) ([]models.PullRequest, error) {
+	var pullRequests []models.PullRequest
+	err := tx.
+		Preload("AssignedReviewers.User").
+		Preload("Author").
+		Where("status = ? AND author_id = ?", "OPEN", authorID).
+		Order("pull_request_id").
+		Find(&pullRequests).Error
+
+	return pullRequests, err
 }
 
 func (r *PullRequestRepository) Transaction(fn func(*gorm.DB) error) error {
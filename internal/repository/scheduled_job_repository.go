@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"CodeRewievService/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ScheduledJobRepository struct {
+	database *gorm.DB
+}
+
+func NewScheduledJobRepository(database *gorm.DB) *ScheduledJobRepository {
+	return &ScheduledJobRepository{
+		database: database,
+	}
+}
+
+func (r *ScheduledJobRepository) CreateInTx(tx *gorm.DB, job *models.ScheduledJob) error {
+	return tx.Create(job).Error
+}
+
+func (r *ScheduledJobRepository) FindByID(jobID int64) (*models.ScheduledJob, error) {
+	var job models.ScheduledJob
+	if err := r.database.Where("id = ?", jobID).Take(&job).Error; err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (r *ScheduledJobRepository) FindByIDForUpdateInTx(tx *gorm.DB, jobID int64) (*models.ScheduledJob, error) {
+	var job models.ScheduledJob
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", jobID).Take(&job).Error; err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (r *ScheduledJobRepository) List(filter models.ScheduledJobFilter) ([]models.ScheduledJob, error) {
+	query := r.database.Model(&models.ScheduledJob{})
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.JobType != "" {
+		query = query.Where("job_type = ?", filter.JobType)
+	}
+
+	jobs := []models.ScheduledJob{}
+	err := query.Order("run_at DESC, id DESC").Limit(filter.Limit).Find(&jobs).Error
+	return jobs, err
+}
+
+// ClaimDue marks due pending jobs as running in a short transaction, so concurrent workers skip them and they
+// can no longer be cancelled while they execute.
+func (r *ScheduledJobRepository) ClaimDue(now time.Time, limit int) ([]models.ScheduledJob, error) {
+	var jobs []models.ScheduledJob
+	err := r.database.Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND run_at <= ?", models.ScheduledJobPending, now).
+			Order("run_at, id").
+			Limit(limit).
+			Find(&jobs).Error
+		if err != nil || len(jobs) == 0 {
+			return err
+		}
+
+		ids := make([]int64, len(jobs))
+		for i := range jobs {
+			ids[i] = jobs[i].ID
+			jobs[i].Status = models.ScheduledJobRunning
+			jobs[i].ClaimedAt = &now
+		}
+
+		return tx.Model(&models.ScheduledJob{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{"status": models.ScheduledJobRunning, "claimed_at": now}).Error
+	})
+
+	return jobs, err
+}
+
+// Release returns claimed jobs that have not started to PENDING, so the next run picks them up.
+func (r *ScheduledJobRepository) Release(jobIDs []int64) error {
+	return r.database.Model(&models.ScheduledJob{}).
+		Where("id IN ? AND status = ?", jobIDs, models.ScheduledJobRunning).
+		Updates(map[string]interface{}{"status": models.ScheduledJobPending, "claimed_at": nil}).Error
+}
+
+// FailStale marks jobs claimed before claimedBefore that are still RUNNING as FAILED: their worker died while
+// executing them, and whether the change was applied is unknown, so they are not retried.
+func (r *ScheduledJobRepository) FailStale(claimedBefore, now time.Time, reason string) (int64, error) {
+	result := r.database.Model(&models.ScheduledJob{}).
+		Where("status = ? AND (claimed_at IS NULL OR claimed_at < ?)", models.ScheduledJobRunning, claimedBefore).
+		Updates(map[string]interface{}{
+			"status":      models.ScheduledJobFailed,
+			"finished_at": now,
+			"error":       reason,
+		})
+
+	return result.RowsAffected, result.Error
+}
+
+func (r *ScheduledJobRepository) Finish(job *models.ScheduledJob) error {
+	return r.FinishInTx(r.database, job)
+}
+
+func (r *ScheduledJobRepository) FinishInTx(tx *gorm.DB, job *models.ScheduledJob) error {
+	return tx.Model(&models.ScheduledJob{}).
+		Where("id = ?", job.ID).
+		Updates(map[string]interface{}{
+			"status":      job.Status,
+			"finished_at": job.FinishedAt,
+			"result":      job.Result,
+			"error":       job.Error,
+		}).Error
+}
+
+func (r *ScheduledJobRepository) Transaction(fn func(*gorm.DB) error) error {
+	return r.database.Transaction(fn)
+}
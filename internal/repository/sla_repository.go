@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"CodeRewievService/internal/models"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SLARepository struct {
+	database *gorm.DB
+}
+
+func NewSLARepository(database *gorm.DB) *SLARepository {
+	return &SLARepository{
+		database: database,
+	}
+}
+
+func (r *SLARepository) FindByTeam(teamName string) (*models.ReviewSLA, error) {
+	var sla models.ReviewSLA
+	result := r.database.Where("team_name = ?", teamName).First(&sla)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &sla, nil
+}
+
+func (r *SLARepository) Upsert(sla *models.ReviewSLA) error {
+	return r.database.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "team_name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"first_review_minutes", "action", "updated_at"}),
+		}).
+		Create(sla).Error
+}
+
+func (r *SLARepository) Delete(teamName string) (int64, error) {
+	result := r.database.Where("team_name = ?", teamName).Delete(&models.ReviewSLA{})
+	return result.RowsAffected, result.Error
+}
+
+// GetPendingReviewsOlderThan returns open review assignments of PRs whose team (the repository team, else the
+// author's) has a configured SLA, that are older than the SLA in wall-clock time and have not been recorded as breaches yet. Wall-clock age is a lower bound
+// of the business-time deadline, which the service checks on the team's calendar.
+func (r *SLARepository) GetPendingReviewsOlderThan(now time.Time) ([]models.PendingReview, error) {
+	var pending []models.PendingReview
+	err := r.database.
+		Table("pull_request_reviewers AS prr").
+		Select("prr.pull_request_id, prr.user_id, slas.team_name, prr.assigned_at, "+
+			"slas.first_review_minutes, slas.action").
+		Joins("JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id").
+		Joins("JOIN users authors ON authors.user_id = pr.author_id").
+		Joins("JOIN team_review_slas slas ON slas.team_name = COALESCE(NULLIF(pr.team_name, ''), authors.team_name)").
+		Where("pr.status = ? AND pr.sla_escalated_at IS NULL", "OPEN").
+		Where("prr.assigned_at + make_interval(mins => slas.first_review_minutes) < ?", now).
+		Where("NOT EXISTS (SELECT 1 FROM review_sla_breaches b " +
+			"WHERE b.pull_request_id = prr.pull_request_id AND b.user_id = prr.user_id AND b.assigned_at = prr.assigned_at)").
+		Order("prr.assigned_at").
+		Scan(&pending).Error
+
+	return pending, err
+}
+
+// ClaimEscalation marks the breached PR as escalated and records the breach; false means the PR has already
+// been escalated, by an earlier check or another worker.
+func (r *SLARepository) ClaimEscalation(breach *models.ReviewSLABreach) (bool, error) {
+	claimed := false
+	err := r.database.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.PullRequest{}).
+			Where("pull_request_id = ? AND sla_escalated_at IS NULL", breach.PullRequestID).
+			UpdateColumn("sla_escalated_at", breach.DetectedAt)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		result = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(breach)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		claimed = result.RowsAffected > 0
+		return nil
+	})
+
+	return claimed, err
+}
+
+// ReleaseEscalation undoes ClaimEscalation after its action failed, so the next check escalates the PR again.
+func (r *SLARepository) ReleaseEscalation(breach *models.ReviewSLABreach) error {
+	return r.database.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.ReviewSLABreach{}, breach.ID).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.PullRequest{}).
+			Where("pull_request_id = ?", breach.PullRequestID).
+			UpdateColumn("sla_escalated_at", nil).Error
+	})
+}
+
+func (r *SLARepository) UpdateBreachResult(breachID int64, actionResult string) error {
+	return r.database.Model(&models.ReviewSLABreach{}).
+		Where("id = ?", breachID).
+		Update("action_result", actionResult).Error
+}
+
+// GetCurrentBreaches lists breaches whose PR is still open and whose reviewer assignment is unchanged.
+func (r *SLARepository) GetCurrentBreaches(teamName string) ([]models.OverdueReview, error) {
+	var overdue []models.OverdueReview
+	query := r.database.
+		Table("review_sla_breaches AS b").
+		Select("b.pull_request_id, pr.pull_request_name, b.user_id, b.team_name, b.assigned_at, "+
+			"b.deadline_at, b.detected_at, b.action, b.action_result").
+		Joins("JOIN pull_requests pr ON pr.pull_request_id = b.pull_request_id").
+		Joins("JOIN pull_request_reviewers prr ON prr.pull_request_id = b.pull_request_id "+
+			"AND prr.user_id = b.user_id AND prr.assigned_at = b.assigned_at").
+		Where("pr.status = ?", "OPEN")
+
+	if teamName != "" {
+		query = query.Where("b.team_name = ?", teamName)
+	}
+
+	err := query.Order("b.deadline_at").Scan(&overdue).Error
+	return overdue, err
+}
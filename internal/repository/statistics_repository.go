@@ -2,8 +2,10 @@ package repository
 
 import (
 	"CodeRewievService/internal/models"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type StatisticsRepository struct {
@@ -15,19 +17,256 @@ func NewStatisticsRepository(database *gorm.DB) *StatisticsRepository {
 		database: database,
 	}
 }
-func (r *StatisticsRepository) GetTeamUsers(teamName string) ([]models.User, error) {
-	var users []models.User
-	err := r.database.Where("team_name = ?", teamName).Find(&users).Error
-	return users, err
+
+func (r *StatisticsRepository) GetSubTeamNames(teamName string) ([]string, error) {
+	return subTeamNames(r.database, teamName)
+}
+
+// GetAssignmentCounts returns review counts of every member of the teams in one grouped query; reviews count
+// towards the teams only when they were assigned on behalf of one of them. The window limits assignments by
+// assigned_at and reassignments by occurred_at, while the open load is always the current one.
+func (r *StatisticsRepository) GetAssignmentCounts(teamNames []string, from, to *time.Time) ([]models.AssignmentStats, error) {
+	reviewerJoin := "LEFT JOIN pull_request_reviewers prr ON prr.user_id = u.user_id AND prr.team_name IN ?"
+	reviewerArgs := []interface{}{teamNames}
+	eventWindow := ""
+	eventArgs := []interface{}{[]string{models.PullRequestEventReviewerReassigned, models.PullRequestEventReviewerRemoved}}
+	if from != nil {
+		reviewerJoin += " AND prr.assigned_at >= ?"
+		reviewerArgs = append(reviewerArgs, *from)
+		eventWindow += " AND e.occurred_at >= ?"
+		eventArgs = append(eventArgs, *from)
+	}
+	if to != nil {
+		reviewerJoin += " AND prr.assigned_at < ?"
+		reviewerArgs = append(reviewerArgs, *to)
+		eventWindow += " AND e.occurred_at < ?"
+		eventArgs = append(eventArgs, *to)
+	}
+
+	var stats []models.AssignmentStats
+	err := r.database.
+		Table("users AS u").
+		Select(`u.user_id, u.username, u.is_active,
+			COUNT(pr.pull_request_id) AS assigned_reviews,
+			COUNT(pr.pull_request_id) FILTER (WHERE pr.status = 'OPEN') AS open_reviews,
+			COUNT(pr.pull_request_id) FILTER (WHERE pr.status = 'MERGED') AS merged_reviews,
+			COUNT(pr.pull_request_id) FILTER (WHERE pr.status = 'CLOSED') AS closed_reviews,
+			(SELECT COUNT(*) FROM pull_request_reviewers lr
+				JOIN pull_requests lr_pr ON lr_pr.pull_request_id = lr.pull_request_id
+				WHERE lr.user_id = u.user_id AND lr_pr.status = 'OPEN') AS open_load,
+			(SELECT COUNT(*) FROM pull_request_events e
+				WHERE e.old_reviewer_id = u.user_id AND e.event_type IN ?`+eventWindow+`) AS reassigned_away`,
+			eventArgs...).
+		Joins(reviewerJoin, reviewerArgs...).
+		Joins("LEFT JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id").
+		Where("u.user_id IN (?)",
+			r.database.Model(&models.TeamMembership{}).Select("user_id").Where("team_name IN ?", teamNames)).
+		Group("u.user_id, u.username, u.is_active").
+		Order("u.user_id").
+		Scan(&stats).Error
+
+	return stats, err
+}
+
+// GetAssignmentSeries buckets assignments, completed reviews and merged own PRs of the teams' members by
+// period in [from, to). Only non-empty buckets are returned, ordered by member and period.
+func (r *StatisticsRepository) GetAssignmentSeries(teamNames []string, groupBy string, from, to time.Time) ([]models.StatisticsBucket, error) {
+	var buckets []models.StatisticsBucket
+	err := r.database.Raw(`
+		SELECT user_id, period_start,
+			SUM(assignments) AS assignments,
+			SUM(completed_reviews) AS completed_reviews,
+			SUM(merges) AS merges
+		FROM (
+			SELECT prr.user_id, date_trunc(@unit, prr.assigned_at AT TIME ZONE 'UTC') AS period_start,
+				1 AS assignments, 0 AS completed_reviews, 0 AS merges
+			FROM pull_request_reviewers prr
+			WHERE prr.team_name IN @teams AND prr.assigned_at >= @from AND prr.assigned_at < @to
+			UNION ALL
+			SELECT prr.user_id, date_trunc(@unit, pr.merged_at AT TIME ZONE 'UTC'), 0, 1, 0
+			FROM pull_request_reviewers prr
+			JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+			WHERE prr.team_name IN @teams AND pr.status = 'MERGED' AND pr.merged_at >= @from AND pr.merged_at < @to
+			UNION ALL
+			SELECT pr.author_id, date_trunc(@unit, pr.merged_at AT TIME ZONE 'UTC'), 0, 0, 1
+			FROM pull_requests pr
+			JOIN users author ON author.user_id = pr.author_id
+			WHERE (pr.team_name IN @teams OR (pr.team_name = '' AND author.team_name IN @teams))
+				AND pr.status = 'MERGED' AND pr.merged_at >= @from AND pr.merged_at < @to
+		) activity
+		WHERE user_id IN (SELECT user_id FROM team_memberships WHERE team_name IN @teams)
+		GROUP BY user_id, period_start
+		ORDER BY user_id, period_start`,
+		map[string]interface{}{"unit": groupBy, "teams": teamNames, "from": from, "to": to}).
+		Scan(&buckets).Error
+
+	return buckets, err
+}
+
+// GetCycleTimeSamples returns the PRs of the teams merged within the window; a PR without a repository team
+// belongs to the primary team of its author.
+func (r *StatisticsRepository) GetCycleTimeSamples(teamNames []string, from, to *time.Time) ([]models.CycleTimeSample, error) {
+	query := r.database.
+		Table("pull_requests AS pr").
+		Select("pr.pull_request_id, pr.created_at, pr.merged_at").
+		Joins("JOIN users author ON author.user_id = pr.author_id").
+		Where("pr.team_name IN ? OR (pr.team_name = '' AND author.team_name IN ?)", teamNames, teamNames).
+		Where("pr.status = ? AND pr.merged_at IS NOT NULL", "MERGED")
+	query = mergedWithin(query, from, to)
+
+	var samples []models.CycleTimeSample
+	err := query.Scan(&samples).Error
+	return samples, err
+}
+
+// GetReviewerCycleTimeSamples returns the PRs merged within the window together with their reviewers assigned on
+// behalf of the teams.
+func (r *StatisticsRepository) GetReviewerCycleTimeSamples(
+	teamNames []string,
+	from, to *time.Time,
+) ([]models.ReviewerCycleTimeSample, error) {
+	query := r.database.
+		Table("pull_request_reviewers AS prr").
+		Select("prr.user_id, u.username, pr.created_at, pr.merged_at").
+		Joins("JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id").
+		Joins("JOIN users u ON u.user_id = prr.user_id").
+		Where("prr.team_name IN ?", teamNames).
+		Where("pr.status = ? AND pr.merged_at IS NOT NULL", "MERGED")
+	query = mergedWithin(query, from, to)
+
+	var samples []models.ReviewerCycleTimeSample
+	err := query.Scan(&samples).Error
+	return samples, err
+}
+
+// GetReviewTurnaroundSamples returns the first verdict on each review assignment of the teams, for first verdicts
+// submitted within the window.
+func (r *StatisticsRepository) GetReviewTurnaroundSamples(
+	teamNames []string,
+	from, to *time.Time,
+) ([]models.ReviewTurnaroundSample, error) {
+	firstVerdicts := r.database.
+		Table("review_verdicts").
+		Select("pull_request_id, user_id, assigned_at, MIN(submitted_at) AS submitted_at").
+		Where("team_name IN ?", teamNames).
+		Group("pull_request_id, user_id, assigned_at")
+
+	query := r.database.
+		Table("(?) AS v", firstVerdicts).
+		Select("v.user_id, u.username, v.assigned_at, v.submitted_at").
+		Joins("JOIN users u ON u.user_id = v.user_id")
+	if from != nil {
+		query = query.Where("v.submitted_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("v.submitted_at < ?", *to)
+	}
+
+	var samples []models.ReviewTurnaroundSample
+	err := query.Scan(&samples).Error
+	return samples, err
+}
+
+func mergedWithin(query *gorm.DB, from, to *time.Time) *gorm.DB {
+	if from != nil {
+		query = query.Where("pr.merged_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("pr.merged_at < ?", *to)
+	}
+	return query
+}
+
+// GetTeamOverviews returns PR totals of every team that is not archived in one grouped query; a PR without a
+// repository team counts for the primary team of its author, and an open PR is understaffed when it has fewer
+// than requiredReviewers reviewers.
+func (r *StatisticsRepository) GetTeamOverviews(from, to *time.Time, requiredReviewers int) ([]models.TeamOverview, error) {
+	var overviews []models.TeamOverview
+	err := r.database.Raw(`
+		SELECT t.team_name,
+			COUNT(pr.pull_request_id) FILTER (WHERE `+windowCondition("pr.created_at")+`) AS prs_opened,
+			COUNT(pr.pull_request_id) FILTER (WHERE pr.status = 'MERGED' AND `+windowCondition("pr.merged_at")+`) AS prs_merged,
+			COUNT(pr.pull_request_id) FILTER (WHERE pr.status = 'OPEN') AS open_now,
+			COALESCE(AVG(reviewers.reviewer_count) FILTER (WHERE `+windowCondition("pr.created_at")+`), 0) AS avg_reviewers_per_pr,
+			COUNT(pr.pull_request_id) FILTER (WHERE pr.status = 'OPEN' AND reviewers.reviewer_count < @required) AS understaffed_prs
+		FROM teams t
+		LEFT JOIN (pull_requests pr JOIN users author ON author.user_id = pr.author_id)
+			ON pr.team_name = t.team_name OR (pr.team_name = '' AND author.team_name = t.team_name)
+		LEFT JOIN LATERAL (
+			SELECT COUNT(*) AS reviewer_count FROM pull_request_reviewers prr WHERE prr.pull_request_id = pr.pull_request_id
+		) reviewers ON TRUE
+		WHERE t.archived_at IS NULL
+		GROUP BY t.team_name
+		ORDER BY t.team_name`,
+		map[string]interface{}{"from": nullableTime(from), "to": nullableTime(to), "required": requiredReviewers}).
+		Scan(&overviews).Error
+
+	return overviews, err
+}
+
+// GetLeaderboard ranks reviewers of all teams with reviews assigned or completed within the window.
+func (r *StatisticsRepository) GetLeaderboard(from, to *time.Time, limit int) ([]models.LeaderboardEntry, error) {
+	var entries []models.LeaderboardEntry
+	err := r.database.Raw(`
+		SELECT user_id, username, team_name, completed_reviews, assigned_reviews, open_reviews
+		FROM (
+			SELECT u.user_id, u.username, COALESCE(u.team_name, '') AS team_name,
+				COUNT(*) FILTER (WHERE pr.status = 'MERGED' AND `+windowCondition("pr.merged_at")+`) AS completed_reviews,
+				COUNT(*) FILTER (WHERE `+windowCondition("prr.assigned_at")+`) AS assigned_reviews,
+				COUNT(*) FILTER (WHERE pr.status = 'OPEN') AS open_reviews
+			FROM pull_request_reviewers prr
+			JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+			JOIN users u ON u.user_id = prr.user_id
+			GROUP BY u.user_id, u.username, u.team_name
+		) reviewers
+		WHERE completed_reviews > 0 OR assigned_reviews > 0
+		ORDER BY completed_reviews DESC, assigned_reviews DESC, user_id
+		LIMIT @limit`,
+		map[string]interface{}{"from": nullableTime(from), "to": nullableTime(to), "limit": limit}).
+		Scan(&entries).Error
+
+	return entries, err
+}
+
+// windowCondition limits column to the optional @from and @to named arguments.
+func windowCondition(column string) string {
+	return "(CAST(@from AS TIMESTAMPTZ) IS NULL OR " + column + " >= @from) AND " +
+		"(CAST(@to AS TIMESTAMPTZ) IS NULL OR " + column + " < @to)"
+}
+
+func nullableTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return *t
+}
+
+// GetActiveTeamNames returns the names of all teams that are not archived.
+func (r *StatisticsRepository) GetActiveTeamNames() ([]string, error) {
+	var names []string
+	err := r.database.Model(&models.Team{}).
+		Where("archived_at IS NULL").
+		Order("team_name").
+		Pluck("team_name", &names).Error
+
+	return names, err
+}
+
+// CreateImbalanceAlertInTx reports false when the team already has an open alert.
+func (r *StatisticsRepository) CreateImbalanceAlertInTx(tx *gorm.DB, alert *models.TeamLoadImbalanceAlert) (bool, error) {
+	result := tx.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(alert)
+
+	return result.RowsAffected > 0, result.Error
 }
 
-func (r *StatisticsRepository) CountUserAssignedReviews(userID string) (int64, error) {
-	var count int64
-	err := r.database.Model(&models.PullRequestReviewer{}).
-		Where("user_id = ?", userID).
-		Count(&count).Error
+func (r *StatisticsRepository) DeleteImbalanceAlert(teamName string) error {
+	return r.database.Where("team_name = ?", teamName).Delete(&models.TeamLoadImbalanceAlert{}).Error
+}
 
-	return count, err
+func (r *StatisticsRepository) Transaction(fn func(*gorm.DB) error) error {
+	return r.database.Transaction(fn)
 }
 
 func (r *StatisticsRepository) TeamExists(teamName string) (bool, error) {
@@ -39,3 +278,17 @@ func (r *StatisticsRepository) TeamExists(teamName string) (bool, error) {
 
 	return exists, err
 }
+
+// GetOpenReviewAssignments returns open reviews of the teams with the time each reviewer was assigned, which is
+// where a review's age starts; reviewers added or swapped in later are younger than the PR.
+func (r *StatisticsRepository) GetOpenReviewAssignments(teamNames []string) ([]models.OpenReviewAssignment, error) {
+	var assignments []models.OpenReviewAssignment
+	err := r.database.
+		Table("pull_request_reviewers AS prr").
+		Select("prr.user_id, prr.assigned_at").
+		Joins("JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id").
+		Where("prr.team_name IN ? AND pr.status = ?", teamNames, "OPEN").
+		Scan(&assignments).Error
+
+	return assignments, err
+}
@@ -3,8 +3,10 @@ package repository
 import (
 	"CodeRewievService/internal/models"
 	"errors"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TeamRepository struct {
@@ -31,61 +33,504 @@ func (r *TeamRepository) FindByName(teamName string) (*models.Team, error) {
 	return &team, nil
 }
 
+func (r *TeamRepository) FindByNameForUpdateInTx(tx *gorm.DB, teamName string) (*models.Team, error) {
+	var team models.Team
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("team_name = ?", teamName).Take(&team).Error; err != nil {
+		return nil, err
+	}
+
+	return &team, nil
+}
+
+// List returns teams with their member counts; archived teams only on request.
+func (r *TeamRepository) List(includeArchived bool) ([]models.TeamSummary, error) {
+	var teams []models.TeamSummary
+	query := r.database.Table("teams t").
+		Select("t.team_name, t.parent_team, t.archived_at, COUNT(tm.user_id) AS member_count").
+		Joins("LEFT JOIN team_memberships tm ON tm.team_name = t.team_name").
+		Group("t.team_name").
+		Order("t.team_name")
+
+	if !includeArchived {
+		query = query.Where("t.archived_at IS NULL")
+	}
+
+	err := query.Scan(&teams).Error
+	return teams, err
+}
+
 func (r *TeamRepository) Create(team *models.Team) error {
 	return r.database.Create(team).Error
 }
 
-func (r *TeamRepository) GetUsersByTeam(teamName string) ([]models.User, error) {
+// GetUsersByTeams returns every member of the teams once, whether it is their primary team or not.
+func (r *TeamRepository) GetUsersByTeams(teamNames []string) ([]models.User, error) {
 	var users []models.User
-	err := r.database.Where("team_name = ?", teamName).Find(&users).Error
+	err := r.database.
+		Where("user_id IN (?)",
+			r.database.Model(&models.TeamMembership{}).Select("user_id").Where("team_name IN ?", teamNames)).
+		Order("user_id").
+		Find(&users).Error
+
 	return users, err
 }
 
+func (r *TeamRepository) FindSubTeamNames(teamName string) ([]string, error) {
+	return subTeamNames(r.database, teamName)
+}
+
+// FindAncestorNamesInTx returns the parent chain of the team, nearest first.
+func (r *TeamRepository) FindAncestorNamesInTx(tx *gorm.DB, teamName string) ([]string, error) {
+	var ancestors []string
+	err := tx.Raw(`
+		WITH RECURSIVE ancestors AS (
+			SELECT parent_team AS team_name, 1 AS depth FROM teams WHERE team_name = ? AND parent_team IS NOT NULL
+			UNION
+			SELECT t.parent_team, a.depth + 1
+			FROM teams t
+			JOIN ancestors a ON t.team_name = a.team_name
+			WHERE t.parent_team IS NOT NULL
+		)
+		SELECT team_name FROM ancestors ORDER BY depth`, teamName).
+		Scan(&ancestors).Error
+
+	return ancestors, err
+}
+
+func (r *TeamRepository) FindParentNamesInTx(tx *gorm.DB, teamNames []string) ([]string, error) {
+	var parents []string
+	if len(teamNames) == 0 {
+		return parents, nil
+	}
+
+	err := tx.Model(&models.Team{}).
+		Distinct("parent_team").
+		Where("team_name IN ? AND parent_team IS NOT NULL", teamNames).
+		Order("parent_team").
+		Pluck("parent_team", &parents).Error
+
+	return parents, err
+}
+
+// FindChildNamesInTx returns the direct sub-teams that are not archived.
+func (r *TeamRepository) FindChildNamesInTx(tx *gorm.DB, parentNames []string) ([]string, error) {
+	var children []string
+	if len(parentNames) == 0 {
+		return children, nil
+	}
+
+	err := tx.Model(&models.Team{}).
+		Where("parent_team IN ? AND archived_at IS NULL", parentNames).
+		Order("team_name").
+		Pluck("team_name", &children).Error
+
+	return children, err
+}
+
+func (r *TeamRepository) ArchiveInTx(tx *gorm.DB, teamName string, archivedAt time.Time) error {
+	return tx.Model(&models.Team{}).Where("team_name = ?", teamName).Update("archived_at", archivedAt).Error
+}
+
+// DeactivateExclusiveMembersInTx deactivates active members of the team who belong to no other team that is
+// not archived, and returns their IDs.
+func (r *TeamRepository) DeactivateExclusiveMembersInTx(tx *gorm.DB, teamName string) ([]string, error) {
+	var userIDs []string
+	if err := tx.Model(&models.User{}).
+		Where("is_active = ?", true).
+		Where("user_id IN (?)",
+			tx.Model(&models.TeamMembership{}).Select("user_id").Where("team_name = ?", teamName)).
+		Where("user_id NOT IN (?)",
+			tx.Table("team_memberships tm").
+				Select("tm.user_id").
+				Joins("JOIN teams t ON t.team_name = tm.team_name AND t.archived_at IS NULL").
+				Where("tm.team_name <> ?", teamName)).
+		Order("user_id").
+		Pluck("user_id", &userIDs).Error; err != nil {
+		return nil, err
+	}
+
+	if len(userIDs) == 0 {
+		return userIDs, nil
+	}
+
+	err := tx.Model(&models.User{}).Where("user_id IN ?", userIDs).Update("is_active", false).Error
+	return userIDs, err
+}
+
+// CountDependentsInTx counts what keeps a team from being deleted: memberships and sub-teams, archived or not, and
+// pull requests, reviews or verdicts attributed to the team, which carry its name without a constraint.
+func (r *TeamRepository) CountDependentsInTx(tx *gorm.DB, teamName string) (int64, error) {
+	var members, subTeams, pullRequests, reviews, verdicts int64
+	if err := tx.Model(&models.TeamMembership{}).Where("team_name = ?", teamName).Count(&members).Error; err != nil {
+		return 0, err
+	}
+
+	if err := tx.Model(&models.Team{}).Where("parent_team = ?", teamName).Count(&subTeams).Error; err != nil {
+		return 0, err
+	}
+
+	if err := tx.Table("pull_requests").Where("team_name = ?", teamName).Count(&pullRequests).Error; err != nil {
+		return 0, err
+	}
+
+	if err := tx.Table("pull_request_reviewers").Where("team_name = ?", teamName).Count(&reviews).Error; err != nil {
+		return 0, err
+	}
+
+	if err := tx.Table("review_verdicts").Where("team_name = ?", teamName).Count(&verdicts).Error; err != nil {
+		return 0, err
+	}
+
+	return members + subTeams + pullRequests + reviews + verdicts, nil
+}
+
+func (r *TeamRepository) DeleteInTx(tx *gorm.DB, teamName string) error {
+	return tx.Where("team_name = ?", teamName).Delete(&models.Team{}).Error
+}
+
+// RenameInTx renames the team. Foreign keys follow through ON UPDATE CASCADE; PR, review and verdict
+// attribution, SLA breaches, scheduled jobs and the team's audit records carry the name without a constraint and
+// are updated here.
+func (r *TeamRepository) RenameInTx(tx *gorm.DB, teamName, newName string) error {
+	if err := tx.Model(&models.Team{}).Where("team_name = ?", teamName).Update("team_name", newName).Error; err != nil {
+		return err
+	}
+
+	tables := []string{"pull_requests", "pull_request_reviewers", "review_verdicts", "review_sla_breaches", "scheduled_jobs"}
+	for _, table := range tables {
+		if err := tx.Table(table).Where("team_name = ?", teamName).Update("team_name", newName).Error; err != nil {
+			return err
+		}
+	}
+
+	return tx.Model(&models.AuditRecord{}).
+		Where("entity_type = ? AND entity_id = ?", models.AuditEntityTeam, teamName).
+		Update("entity_id", newName).Error
+}
+
+func (r *TeamRepository) SetParentInTx(tx *gorm.DB, teamName, parentTeam string) error {
+	var parent interface{}
+	if parentTeam != "" {
+		parent = parentTeam
+	}
+
+	return tx.Model(&models.Team{}).Where("team_name = ?", teamName).Update("parent_team", parent).Error
+}
+
 func (r *TeamRepository) CreateWithUsers(team *models.Team, users []models.User) error {
 	return r.database.Transaction(func(tx *gorm.DB) error {
-		if err := tx.Create(team).Error; err != nil {
+		return r.CreateWithUsersInTx(tx, team, users)
+	})
+}
+
+func (r *TeamRepository) CreateWithUsersInTx(tx *gorm.DB, team *models.Team, users []models.User) error {
+	if err := tx.Create(team).Error; err != nil {
+		return err
+	}
+
+	return r.createTeamMembers(tx, team.TeamName, users)
+}
+
+// DeactivateUsersInTx deactivates active members whose primary team is the team or who belong to no other team
+// that is not archived; members who only also belong to the team keep working in their primary team.
+func (r *TeamRepository) DeactivateUsersInTx(tx *gorm.DB, teamName string) ([]string, error) {
+	var userIDs []string
+	if err := tx.Model(&models.User{}).
+		Where("is_active = ?", true).
+		Where("user_id IN (?)",
+			tx.Model(&models.TeamMembership{}).Select("user_id").Where("team_name = ?", teamName)).
+		Where("team_name = ? OR user_id NOT IN (?)", teamName,
+			tx.Table("team_memberships tm").
+				Select("tm.user_id").
+				Joins("JOIN teams t ON t.team_name = tm.team_name AND t.archived_at IS NULL").
+				Where("tm.team_name <> ?", teamName)).
+		Order("user_id").
+		Pluck("user_id", &userIDs).Error; err != nil {
+		return nil, err
+	}
+
+	if len(userIDs) == 0 {
+		return userIDs, nil
+	}
+
+	err := tx.Model(&models.User{}).
+		Where("user_id IN (?)", userIDs).
+		Update("is_active", false).Error
+
+	return userIDs, err
+}
+
+func (r *TeamRepository) CreateDeactivationInTx(
+	tx *gorm.DB,
+	deactivation *models.TeamDeactivation,
+	userIDs []string,
+	reviews []models.TeamDeactivationReview,
+) error {
+	if err := tx.Create(deactivation).Error; err != nil {
+		return err
+	}
+
+	users := make([]models.TeamDeactivationUser, len(userIDs))
+	for i, userID := range userIDs {
+		users[i] = models.TeamDeactivationUser{DeactivationID: deactivation.ID, UserID: userID}
+	}
+	if len(users) > 0 {
+		if err := tx.Create(&users).Error; err != nil {
 			return err
 		}
+	}
 
-		return r.createTeamMembers(tx, team.TeamName, users)
-	})
+	for i := range reviews {
+		reviews[i].DeactivationID = deactivation.ID
+	}
+	if len(reviews) == 0 {
+		return nil
+	}
+	return tx.Create(&reviews).Error
 }
 
-func (r *TeamRepository) DeactivateUsers(teamName string) (int64, error) {
-	result := r.database.Model(&models.User{}).
-		Where("team_name = ? AND is_active = ?", teamName, true).
-		Update("is_active", false)
+func (r *TeamRepository) FindDeactivationForUpdateInTx(tx *gorm.DB, deactivationID int64) (*models.TeamDeactivation, error) {
+	var deactivation models.TeamDeactivation
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", deactivationID).Take(&deactivation).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &deactivation, nil
+}
+
+func (r *TeamRepository) FindDeactivationUserIDsInTx(tx *gorm.DB, deactivationID int64) ([]string, error) {
+	var userIDs []string
+	err := tx.Model(&models.TeamDeactivationUser{}).
+		Where("deactivation_id = ?", deactivationID).
+		Order("user_id").
+		Pluck("user_id", &userIDs).Error
+
+	return userIDs, err
+}
+
+func (r *TeamRepository) FindDeactivationReviewsInTx(tx *gorm.DB, deactivationID int64) ([]models.TeamDeactivationReview, error) {
+	var reviews []models.TeamDeactivationReview
+	err := tx.Where("deactivation_id = ?", deactivationID).
+		Order("pull_request_id, user_id").
+		Find(&reviews).Error
+
+	return reviews, err
+}
+
+func (r *TeamRepository) MarkDeactivationRestoredInTx(tx *gorm.DB, deactivationID int64, restoredAt time.Time) error {
+	return tx.Model(&models.TeamDeactivation{}).Where("id = ?", deactivationID).Update("restored_at", restoredAt).Error
+}
+
+// ActivateUsersInTx activates the users that are inactive and returns their IDs.
+func (r *TeamRepository) ActivateUsersInTx(tx *gorm.DB, userIDs []string) ([]string, error) {
+	var activated []string
+	if len(userIDs) == 0 {
+		return activated, nil
+	}
+
+	if err := tx.Model(&models.User{}).
+		Where("user_id IN ? AND is_active = ?", userIDs, false).
+		Order("user_id").
+		Pluck("user_id", &activated).Error; err != nil {
+		return nil, err
+	}
+
+	if len(activated) == 0 {
+		return activated, nil
+	}
 
-	return result.RowsAffected, result.Error
+	err := tx.Model(&models.User{}).Where("user_id IN ?", activated).Update("is_active", true).Error
+	return activated, err
 }
 
+// GetOpenPRsByTeam returns open PRs of the team's repositories and, for PRs without a repository team, those
+// of authors whose primary team it is.
 func (r *TeamRepository) GetOpenPRsByTeam(tx *gorm.DB, teamName string) ([]models.PullRequest, error) {
 	var openPRs []models.PullRequest
 	err := tx.
+		Preload("AssignedReviewers").
 		Joins("JOIN users ON pull_requests.author_id = users.user_id").
-		Where("users.team_name = ? AND pull_requests.status = ?", teamName, "OPEN").
+		Where("pull_requests.team_name = ? OR (pull_requests.team_name = '' AND users.team_name = ?)", teamName, teamName).
+		Where("pull_requests.status = ?", "OPEN").
 		Find(&openPRs).Error
 
 	return openPRs, err
 }
 
+func (r *TeamRepository) FindExistingNamesInTx(tx *gorm.DB, teamNames []string) ([]string, error) {
+	var existing []string
+	if len(teamNames) == 0 {
+		return existing, nil
+	}
+
+	err := tx.Model(&models.Team{}).Where("team_name IN ?", teamNames).Pluck("team_name", &existing).Error
+	return existing, err
+}
+
+func (r *TeamRepository) CreateInTx(tx *gorm.DB, team *models.Team) error {
+	return tx.Create(team).Error
+}
+
 func (r *TeamRepository) Transaction(fn func(*gorm.DB) error) error {
 	return r.database.Transaction(fn)
 }
 
+func (r *TeamRepository) FindMembershipsByUserInTx(tx *gorm.DB, userID string) ([]models.TeamMembership, error) {
+	var memberships []models.TeamMembership
+	err := tx.Where("user_id = ?", userID).Order("is_primary DESC, team_name").Find(&memberships).Error
+	return memberships, err
+}
+
+func (r *TeamRepository) FindTeamNamesByUser(userID string) ([]string, error) {
+	return r.FindTeamNamesByUserInTx(r.database, userID)
+}
+
+func (r *TeamRepository) FindTeamNamesByUserInTx(tx *gorm.DB, userID string) ([]string, error) {
+	var teamNames []string
+	err := tx.Model(&models.TeamMembership{}).
+		Where("user_id = ?", userID).
+		Order("team_name").
+		Pluck("team_name", &teamNames).Error
+
+	return teamNames, err
+}
+
+// FindMemberIDsInTx returns members of the teams that are not archived.
+func (r *TeamRepository) FindMemberIDsInTx(tx *gorm.DB, teamNames []string) ([]string, error) {
+	var userIDs []string
+	if len(teamNames) == 0 {
+		return userIDs, nil
+	}
+
+	err := tx.Table("team_memberships tm").
+		Distinct("tm.user_id").
+		Joins("JOIN teams t ON t.team_name = tm.team_name AND t.archived_at IS NULL").
+		Where("tm.team_name IN ?", teamNames).
+		Pluck("tm.user_id", &userIDs).Error
+
+	return userIDs, err
+}
+
+// AddMemberInTx adds a membership; a primary one replaces the user's previous primary team.
+func (r *TeamRepository) AddMemberInTx(tx *gorm.DB, userID, teamName string, primary bool) error {
+	if err := tx.Create(&models.TeamMembership{UserID: userID, TeamName: teamName}).Error; err != nil {
+		return err
+	}
+
+	if !primary {
+		return nil
+	}
+	return r.SetPrimaryTeamInTx(tx, userID, teamName)
+}
+
+// RemoveMemberInTx drops a membership; removing the primary team leaves the user without one.
+func (r *TeamRepository) RemoveMemberInTx(tx *gorm.DB, userID, teamName string) error {
+	var removed []models.TeamMembership
+	if err := tx.
+		Clauses(clause.Returning{}).
+		Where("user_id = ? AND team_name = ?", userID, teamName).
+		Delete(&removed).Error; err != nil {
+		return err
+	}
+
+	if len(removed) == 0 || !removed[0].IsPrimary {
+		return nil
+	}
+	return r.SetPrimaryTeamInTx(tx, userID, "")
+}
+
+// SetPrimaryTeamInTx marks an existing membership as primary and mirrors it in users.team_name;
+// an empty teamName clears the primary team.
+func (r *TeamRepository) SetPrimaryTeamInTx(tx *gorm.DB, userID, teamName string) error {
+	if err := tx.Model(&models.TeamMembership{}).
+		Where("user_id = ? AND is_primary = ?", userID, true).
+		Update("is_primary", false).Error; err != nil {
+		return err
+	}
+
+	var primaryTeam interface{}
+	if teamName != "" {
+		primaryTeam = teamName
+		if err := tx.Model(&models.TeamMembership{}).
+			Where("user_id = ? AND team_name = ?", userID, teamName).
+			Update("is_primary", true).Error; err != nil {
+			return err
+		}
+	}
+
+	return tx.Model(&models.User{}).Where("user_id = ?", userID).Update("team_name", primaryTeam).Error
+}
+
+// MoveMemberInTx replaces the from membership with one in the to team, which the user may already have.
+// An empty from only adds the membership.
+func (r *TeamRepository) MoveMemberInTx(tx *gorm.DB, userID, from, to string, primary bool) error {
+	if from != "" {
+		if err := r.RemoveMemberInTx(tx, userID, from); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.TeamMembership{UserID: userID, TeamName: to}).Error; err != nil {
+		return err
+	}
+
+	if !primary {
+		return nil
+	}
+	return r.SetPrimaryTeamInTx(tx, userID, to)
+}
+
+// createTeamMembers creates new users with the team as their primary one. Existing users keep their teams and
+// get an additional membership, which becomes primary only if they had none.
 func (r *TeamRepository) createTeamMembers(tx *gorm.DB, teamName string, users []models.User) error {
 	for _, member := range users {
-		user := &models.User{
-			UserID:   member.UserID,
-			Username: member.Username,
-			TeamName: teamName,
-			IsActive: member.IsActive,
+		var existing models.User
+		err := tx.Where("user_id = ?", member.UserID).Take(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = r.createUserInTeam(tx, teamName, member)
+		} else if err == nil {
+			err = r.AddMemberInTx(tx, existing.UserID, teamName, existing.TeamName == "")
 		}
 
-		if err := tx.Save(user).Error; err != nil {
+		if err != nil {
 			return err
 		}
 	}
 
 	return nil
 }
+
+// subTeamNames returns all descendants of the team that are not archived, breadth first.
+func subTeamNames(db *gorm.DB, teamName string) ([]string, error) {
+	var names []string
+	err := db.Raw(`
+		WITH RECURSIVE sub_teams AS (
+			SELECT team_name, 1 AS depth FROM teams WHERE parent_team = ? AND archived_at IS NULL
+			UNION
+			SELECT t.team_name, s.depth + 1
+			FROM teams t
+			JOIN sub_teams s ON t.parent_team = s.team_name
+			WHERE t.archived_at IS NULL
+		)
+		SELECT team_name FROM sub_teams ORDER BY depth, team_name`, teamName).
+		Scan(&names).Error
+
+	return names, err
+}
+
+func (r *TeamRepository) createUserInTeam(tx *gorm.DB, teamName string, member models.User) error {
+	user := &models.User{
+		UserID:   member.UserID,
+		Username: member.Username,
+		TeamName: teamName,
+		IsActive: member.IsActive,
+	}
+
+	if err := tx.Create(user).Error; err != nil {
+		return err
+	}
+
+	return tx.Create(&models.TeamMembership{UserID: user.UserID, TeamName: teamName, IsPrimary: true}).Error
+}
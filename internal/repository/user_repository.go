@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserRepository struct {
@@ -49,38 +50,145 @@ func (r *UserRepository) Update(user *models.User) error {
 	return r.database.Save(user).Error
 }
 
-func (r *UserRepository) GetActiveTeamMembers(teamName string, excludeUserID string) ([]models.User, error) {
-	var users []models.User
-	err := r.database.
-		Where("team_name = ? AND user_id != ? AND is_active = ?", teamName, excludeUserID, true).
-		Find(&users).Error
+// UpdateInTx saves the user's own attributes; team membership changes go through TeamRepository.
+func (r *UserRepository) UpdateInTx(tx *gorm.DB, user *models.User) error {
+	return tx.Model(&models.User{}).
+		Where("user_id = ?", user.UserID).
+		Updates(map[string]interface{}{
+			"username":     user.Username,
+			"is_active":    user.IsActive,
+			"email":        user.Email,
+			"display_name": user.DisplayName,
+			"chat_handle":  user.ChatHandle,
+			"timezone":     user.Timezone,
+		}).Error
+}
 
-	return users, err
+// List returns one page of users matching the filter, ordered by ID, and the total number of matches.
+func (r *UserRepository) List(filter models.UserFilter) ([]models.User, int64, error) {
+	query := r.database.Model(&models.User{})
+
+	if filter.TeamName != "" {
+		query = query.Where(
+			"EXISTS (SELECT 1 FROM team_memberships tm WHERE tm.user_id = users.user_id AND tm.team_name = ?)",
+			filter.TeamName,
+		)
+	}
+	if filter.IsActive != nil {
+		query = query.Where("is_active = ?", *filter.IsActive)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	users := []models.User{}
+	err := query.Order("user_id").Limit(filter.Limit).Offset(filter.Offset).Find(&users).Error
+	return users, total, err
+}
+
+// FindByIDForUpdateInTx locks the user row so concurrent membership changes are serialized.
+func (r *UserRepository) FindByIDForUpdateInTx(tx *gorm.DB, userID string) (*models.User, error) {
+	var user models.User
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ?", userID).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
 }
 
-func (r *UserRepository) GetAvailableReviewers(
-	teamName string,
+func (r *UserRepository) Transaction(fn func(*gorm.DB) error) error {
+	return r.database.Transaction(fn)
+}
+
+// GetReviewerCandidatesInTx returns active members of any of the teams that are not archived, once per user.
+// A user in several of the teams is attributed to their primary team when it is among them.
+func (r *UserRepository) GetReviewerCandidatesInTx(
+	tx *gorm.DB,
+	teamNames []string,
 	excludeUserIDs []string,
-	excludeAuthorID string,
-) ([]models.User, error) {
-	var users []models.User
-	query := r.database.
-		Where("team_name = ? AND user_id != ? AND is_active = ?", teamName, excludeAuthorID, true)
+) ([]models.ReviewerCandidate, error) {
+	var candidates []models.ReviewerCandidate
+	if len(teamNames) == 0 {
+		return candidates, nil
+	}
+
+	query := tx.Table("users").
+		Select("DISTINCT ON (users.user_id) users.*, tm.team_name AS via_team").
+		Joins("JOIN team_memberships tm ON tm.user_id = users.user_id").
+		Joins("JOIN teams t ON t.team_name = tm.team_name AND t.archived_at IS NULL").
+		Where("tm.team_name IN ? AND users.is_active = ?", teamNames, true)
 
-	for _, userID := range excludeUserIDs {
-		query = query.Where("user_id != ?", userID)
+	if len(excludeUserIDs) > 0 {
+		query = query.Where("users.user_id NOT IN ?", excludeUserIDs)
 	}
 
-	err := query.Find(&users).Error
-	return users, err
+	err := query.Order("users.user_id, tm.is_primary DESC, tm.team_name").Scan(&candidates).Error
+	return candidates, err
 }
 
 func (r *UserRepository) GetPullRequestsByReviewer(userID string) ([]models.PullRequest, error) {
 	var pullRequests []models.PullRequest
 	err := r.database.
+		Preload("Author").
 		Joins("JOIN pull_request_reviewers ON pull_requests.pull_request_id = pull_request_reviewers.pull_request_id").
 		Where("pull_request_reviewers.user_id = ?", userID).
 		Find(&pullRequests).Error
 
 	return pullRequests, err
 }
+
+func (r *UserRepository) FindByIDsInTx(tx *gorm.DB, userIDs []string) ([]models.User, error) {
+	var users []models.User
+	if len(userIDs) == 0 {
+		return users, nil
+	}
+
+	err := tx.Where("user_id IN ?", userIDs).Find(&users).Error
+	return users, err
+}
+
+func (r *UserRepository) FindByIDsForUpdateInTx(tx *gorm.DB, userIDs []string) ([]models.User, error) {
+	var users []models.User
+	if len(userIDs) == 0 {
+		return users, nil
+	}
+
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id IN ?", userIDs).
+		Order("user_id").
+		Find(&users).Error
+	return users, err
+}
+
+func (r *UserRepository) SetIsActiveInTx(tx *gorm.DB, userIDs []string, isActive bool) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	return tx.Model(&models.User{}).Where("user_id IN ?", userIDs).Update("is_active", isActive).Error
+}
+
+func (r *UserRepository) FindByTeamsInTx(tx *gorm.DB, teamNames []string) ([]models.User, error) {
+	var users []models.User
+	if len(teamNames) == 0 {
+		return users, nil
+	}
+
+	err := tx.Where("team_name IN ?", teamNames).Order("user_id").Find(&users).Error
+	return users, err
+}
+
+// CreateInTx creates the user with user.TeamName as their primary team.
+func (r *UserRepository) CreateInTx(tx *gorm.DB, user *models.User) error {
+	if err := tx.Create(user).Error; err != nil {
+		return err
+	}
+
+	if user.TeamName == "" {
+		return nil
+	}
+	return tx.Create(&models.TeamMembership{UserID: user.UserID, TeamName: user.TeamName, IsPrimary: true}).Error
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"CodeRewievService/internal/models"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookRepository struct {
+	database *gorm.DB
+}
+
+func NewWebhookRepository(database *gorm.DB) *WebhookRepository {
+	return &WebhookRepository{
+		database: database,
+	}
+}
+
+func (r *WebhookRepository) CreateSubscription(subscription *models.WebhookSubscription) error {
+	return r.database.Create(subscription).Error
+}
+
+func (r *WebhookRepository) ListSubscriptions() ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	err := r.database.Order("id").Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (r *WebhookRepository) DeleteSubscription(subscriptionID int64) (int64, error) {
+	result := r.database.Where("id = ?", subscriptionID).Delete(&models.WebhookSubscription{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *WebhookRepository) GetSubscribersInTx(tx *gorm.DB, eventType string) ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	err := tx.
+		Where("is_active = ? AND (',' || events || ',') LIKE ?", true, "%,"+eventType+",%").
+		Find(&subscriptions).Error
+
+	return subscriptions, err
+}
+
+func (r *WebhookRepository) EnqueueInTx(tx *gorm.DB, deliveries []models.WebhookDelivery) error {
+	return tx.Omit(clause.Associations).Create(&deliveries).Error
+}
+
+// LeasePending claims due deliveries by moving their next attempt lease into the future, so concurrent workers
+// skip them while they are being sent and pick them up again if the worker dies.
+func (r *WebhookRepository) LeasePending(now time.Time, lease time.Duration, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := r.database.Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Preload("Subscription").
+			Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]int64, 0, len(deliveries))
+		for _, delivery := range deliveries {
+			ids = append(ids, delivery.ID)
+		}
+
+		return tx.Model(&models.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+
+	return deliveries, err
+}
+
+// RecordAttempt stores the attempt and the resulting delivery state together.
+func (r *WebhookRepository) RecordAttempt(delivery *models.WebhookDelivery, attempt *models.WebhookDeliveryAttempt) error {
+	return r.database.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(attempt).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.WebhookDelivery{}).
+			Where("id = ?", delivery.ID).
+			Updates(map[string]interface{}{
+				"status":          delivery.Status,
+				"attempts":        delivery.Attempts,
+				"next_attempt_at": delivery.NextAttemptAt,
+				"delivered_at":    delivery.DeliveredAt,
+			}).Error
+	})
+}
+
+func (r *WebhookRepository) ListDeliveries(filter models.WebhookDeliveryFilter) ([]models.WebhookDelivery, error) {
+	query := r.database.
+		Preload("AttemptLog", func(db *gorm.DB) *gorm.DB {
+			return db.Order("attempted_at")
+		})
+
+	if filter.SubscriptionID != 0 {
+		query = query.Where("subscription_id = ?", filter.SubscriptionID)
+	}
+	if filter.EventType != "" {
+		query = query.Where("event_type = ?", filter.EventType)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var deliveries []models.WebhookDelivery
+	err := query.Order("id DESC").Limit(filter.Limit).Find(&deliveries).Error
+	return deliveries, err
+}
+
+func (r *WebhookRepository) FindDelivery(deliveryID int64) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	result := r.database.Where("id = ?", deliveryID).First(&delivery)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &delivery, nil
+}
+
+// ResetDelivery queues a delivery in one of the statuses again with a fresh retry budget; its attempt log is
+// kept. False means the delivery is no longer in any of them.
+func (r *WebhookRepository) ResetDelivery(deliveryID int64, statuses []string, now time.Time) (bool, error) {
+	result := r.database.Model(&models.WebhookDelivery{}).
+		Where("id = ? AND status IN ?", deliveryID, statuses).
+		Updates(map[string]interface{}{
+			"status":          models.WebhookDeliveryPending,
+			"attempts":        0,
+			"next_attempt_at": now,
+			"delivered_at":    nil,
+		})
+
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *WebhookRepository) Transaction(fn func(*gorm.DB) error) error {
+	return r.database.Transaction(fn)
+}
@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type Task func(ctx context.Context) error
+
+type PeriodicJob struct {
+	name     string
+	interval time.Duration
+	task     Task
+	logger   *slog.Logger
+}
+
+func NewPeriodicJob(name string, interval time.Duration, task Task, logger *slog.Logger) *PeriodicJob {
+	return &PeriodicJob{
+		name:     name,
+		interval: interval,
+		task:     task,
+		logger:   logger,
+	}
+}
+
+func (j *PeriodicJob) Name() string {
+	return j.name
+}
+
+func (j *PeriodicJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	j.logger.Info("Background job started", "job", j.name, "interval", j.interval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			j.logger.Info("Background job stopped", "job", j.name)
+			return
+		case <-ticker.C:
+			j.runOnce(ctx)
+		}
+	}
+}
+
+func (j *PeriodicJob) runOnce(ctx context.Context) {
+	start := time.Now()
+	if err := j.task(ctx); err != nil {
+		j.logger.Error("Background job iteration failed", "job", j.name, "error", err)
+		return
+	}
+	j.logger.Debug("Background job iteration finished", "job", j.name, "duration", time.Since(start))
+}
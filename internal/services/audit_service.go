@@ -0,0 +1,110 @@
+package services
+
+import (
+	"CodeRewievService/internal/audit"
+	"CodeRewievService/internal/models"
+	"CodeRewievService/internal/repository"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultAuditPageLimit = 100
+	maxAuditPageLimit     = 1000
+)
+
+type AuditService struct {
+	auditRepository *repository.AuditRepository
+}
+
+func NewAuditService(auditRepository *repository.AuditRepository) *AuditService {
+	return &AuditService{
+		auditRepository: auditRepository,
+	}
+}
+
+// RecordInTx writes an audit record as part of the caller's transaction, so it exists exactly when the change does.
+// A nil before or after snapshot means the entity did not exist on that side of the operation.
+func (s *AuditService) RecordInTx(
+	ctx context.Context,
+	tx *gorm.DB,
+	operation, entityType, entityID string,
+	before, after interface{},
+) error {
+	record, err := newAuditRecord(ctx, operation, entityType, models.AuditChange{
+		EntityID: entityID,
+		Before:   before,
+		After:    after,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.auditRepository.CreateInTx(tx, record)
+}
+
+// RecordManyInTx is RecordInTx for a batch of entities changed by the same operation, written in one insert.
+func (s *AuditService) RecordManyInTx(
+	ctx context.Context,
+	tx *gorm.DB,
+	operation, entityType string,
+	changes []models.AuditChange,
+) error {
+	records := make([]models.AuditRecord, len(changes))
+	for i, change := range changes {
+		record, err := newAuditRecord(ctx, operation, entityType, change)
+		if err != nil {
+			return err
+		}
+		records[i] = *record
+	}
+
+	return s.auditRepository.CreateManyInTx(tx, records)
+}
+
+func newAuditRecord(ctx context.Context, operation, entityType string, change models.AuditChange) (*models.AuditRecord, error) {
+	record := &models.AuditRecord{
+		Actor:      audit.Actor(ctx),
+		Operation:  operation,
+		EntityType: entityType,
+		EntityID:   change.EntityID,
+		RequestID:  audit.RequestID(ctx),
+	}
+
+	var err error
+	if record.Before, err = auditSnapshot(change.Before); err != nil {
+		return nil, err
+	}
+	if record.After, err = auditSnapshot(change.After); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func (s *AuditService) List(filter models.AuditFilter) ([]models.AuditRecord, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditPageLimit
+	}
+	if filter.Limit > maxAuditPageLimit {
+		filter.Limit = maxAuditPageLimit
+	}
+
+	return s.auditRepository.List(filter)
+}
+
+func auditSnapshot(value interface{}) (json.RawMessage, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	snapshot, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit snapshot: %w", err)
+	}
+
+	return snapshot, nil
+}
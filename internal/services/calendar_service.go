@@ -0,0 +1,309 @@
+package services
+
+import (
+	"CodeRewievService/internal/calendar"
+	"CodeRewievService/internal/models"
+	"CodeRewievService/internal/repository"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultTimezone  = "UTC"
+	defaultWorkDays  = "1,2,3,4,5"
+	defaultWorkStart = 9 * 60
+	defaultWorkEnd   = 18 * 60
+)
+
+type CalendarService struct {
+	calendarRepository *repository.CalendarRepository
+	teamRepository     *repository.TeamRepository
+}
+
+func NewCalendarService(
+	calendarRepository *repository.CalendarRepository,
+	teamRepository *repository.TeamRepository,
+) *CalendarService {
+	return &CalendarService{
+		calendarRepository: calendarRepository,
+		teamRepository:     teamRepository,
+	}
+}
+
+func (s *CalendarService) SetTeamCalendar(req *models.RequestSetTeamCalendar) (*models.TeamCalendarDTO, error) {
+	teamCalendar, err := s.buildTeamCalendar(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateTeamExists(req.TeamName); err != nil {
+		return nil, err
+	}
+
+	if err := s.calendarRepository.Upsert(teamCalendar); err != nil {
+		return nil, err
+	}
+
+	return s.GetTeamCalendar(req.TeamName)
+}
+
+func (s *CalendarService) GetTeamCalendar(teamName string) (*models.TeamCalendarDTO, error) {
+	if teamName == "" {
+		return nil, errors.New("team name cannot be empty")
+	}
+
+	if err := s.validateTeamExists(teamName); err != nil {
+		return nil, err
+	}
+
+	teamCalendar, err := s.findTeamCalendar(teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	holidays, err := s.calendarRepository.GetHolidays(teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TeamCalendarDTO{
+		TeamName:  teamName,
+		Timezone:  teamCalendar.Timezone,
+		WorkDays:  parseWorkDays(teamCalendar.WorkDays),
+		WorkStart: formatMinuteOfDay(teamCalendar.WorkStartMinute),
+		WorkEnd:   formatMinuteOfDay(teamCalendar.WorkEndMinute),
+		Holidays:  holidays,
+	}, nil
+}
+
+func (s *CalendarService) ImportHolidays(teamName, format string, reader io.Reader, replace bool) (int, error) {
+	if teamName == "" {
+		return 0, errors.New("team name cannot be empty")
+	}
+
+	if err := s.validateTeamExists(teamName); err != nil {
+		return 0, err
+	}
+
+	parsed, err := calendar.ParseHolidays(format, reader)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", models.ErrInvalidHolidaysFile, err)
+	}
+
+	holidays := deduplicateHolidays(teamName, parsed)
+	if err := s.calendarRepository.SaveHolidays(teamName, holidays, replace); err != nil {
+		return 0, err
+	}
+
+	return len(holidays), nil
+}
+
+// ForTeam builds the business calendar of a team; teams without a configured calendar count wall-clock time.
+func (s *CalendarService) ForTeam(teamName string) (*calendar.Calendar, error) {
+	teamCalendar, err := s.calendarRepository.FindByTeam(teamName)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return calendar.AlwaysOpen(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	holidays, err := s.calendarRepository.GetHolidays(teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildCalendar(teamCalendar, holidays)
+}
+
+func (s *CalendarService) BusinessElapsed(teamName string, from, to time.Time) (time.Duration, error) {
+	teamCalendar, err := s.ForTeam(teamName)
+	if err != nil {
+		return 0, err
+	}
+
+	return teamCalendar.BusinessElapsed(from, to), nil
+}
+
+// PullRequestAge is the business time since creation, measured until merge for merged PRs.
+func (s *CalendarService) PullRequestAge(teamCalendar *calendar.Calendar, pr *models.PullRequest, now time.Time) time.Duration {
+	end := now
+	if pr.MergedAt != nil {
+		end = *pr.MergedAt
+	}
+
+	return teamCalendar.BusinessElapsed(pr.CreatedAt, end)
+}
+
+func (s *CalendarService) findTeamCalendar(teamName string) (*models.TeamCalendar, error) {
+	teamCalendar, err := s.calendarRepository.FindByTeam(teamName)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &models.TeamCalendar{
+			TeamName:        teamName,
+			Timezone:        defaultTimezone,
+			WorkDays:        "1,2,3,4,5,6,7",
+			WorkStartMinute: 0,
+			WorkEndMinute:   24 * 60,
+		}, nil
+	}
+
+	return teamCalendar, err
+}
+
+func (s *CalendarService) buildTeamCalendar(req *models.RequestSetTeamCalendar) (*models.TeamCalendar, error) {
+	if req == nil || req.TeamName == "" {
+		return nil, errors.New("team name cannot be empty")
+	}
+
+	teamCalendar := &models.TeamCalendar{
+		TeamName:        req.TeamName,
+		Timezone:        defaultTimezone,
+		WorkDays:        defaultWorkDays,
+		WorkStartMinute: defaultWorkStart,
+		WorkEndMinute:   defaultWorkEnd,
+	}
+
+	if req.Timezone != "" {
+		teamCalendar.Timezone = req.Timezone
+	}
+
+	if len(req.WorkDays) > 0 {
+		workDays, err := formatWorkDays(req.WorkDays)
+		if err != nil {
+			return nil, err
+		}
+		teamCalendar.WorkDays = workDays
+	}
+
+	var err error
+	if req.WorkStart != "" {
+		if teamCalendar.WorkStartMinute, err = parseMinuteOfDay(req.WorkStart); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.WorkEnd != "" {
+		if teamCalendar.WorkEndMinute, err = parseMinuteOfDay(req.WorkEnd); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := buildCalendar(teamCalendar, nil); err != nil {
+		return nil, err
+	}
+
+	return teamCalendar, nil
+}
+
+func (s *CalendarService) validateTeamExists(teamName string) error {
+	_, err := s.teamRepository.FindByName(teamName)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.ErrTeamNotFound
+	}
+	return err
+}
+
+func buildCalendar(teamCalendar *models.TeamCalendar, holidays []models.TeamHoliday) (*calendar.Calendar, error) {
+	location, err := time.LoadLocation(teamCalendar.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown timezone %q", models.ErrInvalidCalendar, teamCalendar.Timezone)
+	}
+
+	workDays := make([]time.Weekday, 0, 7)
+	for _, isoDay := range parseWorkDays(teamCalendar.WorkDays) {
+		workDays = append(workDays, time.Weekday(isoDay%7))
+	}
+
+	holidayDates := make([]time.Time, len(holidays))
+	for i, holiday := range holidays {
+		holidayDates[i] = holiday.HolidayDate
+	}
+
+	result, err := calendar.New(
+		location,
+		workDays,
+		time.Duration(teamCalendar.WorkStartMinute)*time.Minute,
+		time.Duration(teamCalendar.WorkEndMinute)*time.Minute,
+		holidayDates,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidCalendar, err)
+	}
+
+	return result, nil
+}
+
+func deduplicateHolidays(teamName string, parsed []calendar.Holiday) []models.TeamHoliday {
+	seen := make(map[string]int, len(parsed))
+	holidays := make([]models.TeamHoliday, 0, len(parsed))
+
+	for _, holiday := range parsed {
+		key := holiday.Date.Format(time.DateOnly)
+		if index, ok := seen[key]; ok {
+			holidays[index].Name = holiday.Name
+			continue
+		}
+
+		seen[key] = len(holidays)
+		holidays = append(holidays, models.TeamHoliday{
+			TeamName:    teamName,
+			HolidayDate: holiday.Date,
+			Name:        holiday.Name,
+		})
+	}
+
+	return holidays
+}
+
+// Working days use ISO numbering: 1 is Monday, 7 is Sunday.
+func formatWorkDays(days []int) (string, error) {
+	seen := make(map[int]bool, len(days))
+	parts := make([]string, 0, len(days))
+
+	for _, day := range days {
+		if day < 1 || day > 7 {
+			return "", fmt.Errorf("%w: working day %d is out of range 1..7", models.ErrInvalidCalendar, day)
+		}
+		if seen[day] {
+			continue
+		}
+		seen[day] = true
+		parts = append(parts, strconv.Itoa(day))
+	}
+
+	return strings.Join(parts, ","), nil
+}
+
+func parseWorkDays(value string) []int {
+	var days []int
+	for _, part := range strings.Split(value, ",") {
+		day, err := strconv.Atoi(strings.TrimSpace(part))
+		if err == nil && day >= 1 && day <= 7 {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+func parseMinuteOfDay(value string) (int, error) {
+	if value == "24:00" {
+		return 24 * 60, nil
+	}
+
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: time %q must be in HH:MM format", models.ErrInvalidCalendar, value)
+	}
+
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+func formatMinuteOfDay(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
@@ -0,0 +1,522 @@
+package services
+
+import (
+	"CodeRewievService/internal/audit"
+	"CodeRewievService/internal/integrations"
+	"CodeRewievService/internal/models"
+	"CodeRewievService/internal/repository"
+	"CodeRewievService/internal/webhooks"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const (
+	integrationResultCreated       = "created"
+	integrationResultAlreadyExists = "already_exists"
+	integrationResultAlreadyMerged = "already_merged"
+	integrationResultMerged        = "merged"
+	integrationResultClosed        = "closed"
+	integrationResultReopened      = "reopened"
+	integrationResultReviewed      = "review_recorded"
+	integrationResultIgnored       = "ignored"
+	integrationResultPong          = "pong"
+
+	githubEventPing              = "ping"
+	githubEventPullRequest       = "pull_request"
+	githubEventPullRequestReview = "pull_request_review"
+)
+
+type IntegrationConfig struct {
+	GitHubWebhookSecret string
+	GitLabWebhookToken  string
+}
+
+type IntegrationService struct {
+	integrationRepository *repository.IntegrationRepository
+	userRepository        *repository.UserRepository
+	prService             *PullRequestService
+	github                *integrations.GitHubClient
+	config                IntegrationConfig
+	logger                *slog.Logger
+}
+
+func NewIntegrationService(
+	integrationRepository *repository.IntegrationRepository,
+	userRepository *repository.UserRepository,
+	prService *PullRequestService,
+	github *integrations.GitHubClient,
+	config IntegrationConfig,
+	logger *slog.Logger,
+) *IntegrationService {
+	return &IntegrationService{
+		integrationRepository: integrationRepository,
+		userRepository:        userRepository,
+		prService:             prService,
+		github:                github,
+		config:                config,
+		logger:                logger,
+	}
+}
+
+// HandleGitHubEvent applies a signed GitHub webhook once per delivery ID; repeated deliveries are reported as duplicates.
+func (s *IntegrationService) HandleGitHubEvent(
+	ctx context.Context,
+	event, deliveryID, signature string,
+	body []byte,
+) (*models.IntegrationResult, error) {
+	if s.config.GitHubWebhookSecret == "" {
+		return nil, models.ErrIntegrationDisabled
+	}
+
+	if !webhooks.Verify(s.config.GitHubWebhookSecret, body, signature) {
+		return nil, models.ErrInvalidSignature
+	}
+
+	if event == "" || deliveryID == "" {
+		return nil, fmt.Errorf("%w: event and delivery headers are required", models.ErrInvalidIntegrationEvent)
+	}
+
+	var payload integrations.GitHubPullRequestEvent
+	if event == githubEventPullRequest || event == githubEventPullRequestReview {
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("%w: %v", models.ErrInvalidIntegrationEvent, err)
+		}
+	}
+
+	result := &models.IntegrationResult{
+		Provider:   integrations.ProviderGitHub,
+		DeliveryID: deliveryID,
+		Event:      event,
+		Action:     payload.Action,
+	}
+
+	return s.processDelivery(result, func() (string, error) {
+		switch event {
+		case githubEventPing:
+			return integrationResultPong, nil
+		case githubEventPullRequest:
+			result.PullRequestID = integrations.GitHubPullRequestID(payload.Repository.FullName, payload.PullRequest.Number)
+			return s.applyGitHubPullRequestEvent(
+				audit.WithActor(ctx, integrationActor(integrations.ProviderGitHub, payload.Sender.Login)),
+				&payload,
+				result.PullRequestID,
+			)
+		case githubEventPullRequestReview:
+			result.PullRequestID = integrations.GitHubPullRequestID(payload.Repository.FullName, payload.PullRequest.Number)
+			return s.applyGitHubReviewEvent(
+				audit.WithActor(ctx, integrationActor(integrations.ProviderGitHub, payload.Sender.Login)),
+				&payload,
+				result.PullRequestID,
+			)
+		default:
+			return integrationResultIgnored, nil
+		}
+	})
+}
+
+// HandleGitLabEvent applies a GitLab Merge Request Hook authenticated by the shared webhook token;
+// replays of the same event are reported as duplicates.
+func (s *IntegrationService) HandleGitLabEvent(
+	ctx context.Context,
+	event, token, deliveryID string,
+	body []byte,
+) (*models.IntegrationResult, error) {
+	if s.config.GitLabWebhookToken == "" {
+		return nil, models.ErrIntegrationDisabled
+	}
+
+	if subtle.ConstantTimeCompare([]byte(s.config.GitLabWebhookToken), []byte(token)) != 1 {
+		return nil, models.ErrInvalidSignature
+	}
+
+	var payload integrations.GitLabMergeRequestEvent
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidIntegrationEvent, err)
+	}
+
+	result := &models.IntegrationResult{
+		Provider:   integrations.ProviderGitLab,
+		DeliveryID: deliveryID,
+		Event:      event,
+		Action:     payload.ObjectAttributes.Action,
+	}
+
+	return s.processDelivery(result, func() (string, error) {
+		if payload.ObjectKind != integrations.GitLabObjectKindMergeRequest {
+			return integrationResultIgnored, nil
+		}
+
+		result.PullRequestID = integrations.GitLabPullRequestID(
+			payload.Project.PathWithNamespace,
+			payload.ObjectAttributes.IID,
+		)
+		return s.applyGitLabMergeRequestEvent(
+			audit.WithActor(ctx, integrationActor(integrations.ProviderGitLab, payload.User.Username)),
+			&payload,
+			result.PullRequestID,
+		)
+	})
+}
+
+// processDelivery runs apply once per provider delivery. A failed delivery is forgotten so that the
+// provider's retry is processed again.
+func (s *IntegrationService) processDelivery(
+	result *models.IntegrationResult,
+	apply func() (string, error),
+) (*models.IntegrationResult, error) {
+	claimed, err := s.integrationRepository.ClaimDelivery(&models.IntegrationDelivery{
+		Provider:   result.Provider,
+		DeliveryID: result.DeliveryID,
+		Event:      result.Event,
+		Action:     result.Action,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !claimed {
+		result.Duplicate = true
+		return result, nil
+	}
+
+	result.Result, err = apply()
+	if err != nil {
+		if releaseErr := s.integrationRepository.ReleaseDelivery(result.Provider, result.DeliveryID); releaseErr != nil {
+			s.logger.Error("Failed to release integration delivery",
+				"error", releaseErr,
+				"provider", result.Provider,
+				"deliveryID", result.DeliveryID,
+			)
+		}
+		return nil, err
+	}
+
+	if err := s.integrationRepository.CompleteDelivery(result.Provider, result.DeliveryID, result.Result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *IntegrationService) MapUser(req *models.RequestMapExternalUser) (*models.ExternalUserMapping, error) {
+	if req == nil || req.ExternalLogin == "" || req.UserID == "" || !integrations.IsKnownProvider(req.Provider) {
+		return nil, models.ErrInvalidUserMapping
+	}
+
+	_, err := s.userRepository.FindByID(req.UserID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, models.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	mapping := &models.ExternalUserMapping{
+		Provider:      req.Provider,
+		ExternalLogin: req.ExternalLogin,
+		UserID:        req.UserID,
+	}
+
+	if err := s.integrationRepository.UpsertUserMapping(mapping); err != nil {
+		return nil, err
+	}
+
+	return mapping, nil
+}
+
+func (s *IntegrationService) ListUserMappings(provider string) ([]models.ExternalUserMapping, error) {
+	return s.integrationRepository.ListUserMappings(provider)
+}
+
+func (s *IntegrationService) applyGitHubPullRequestEvent(
+	ctx context.Context,
+	payload *integrations.GitHubPullRequestEvent,
+	prID string,
+) (string, error) {
+	if payload.Repository.FullName == "" || payload.PullRequest.Number == 0 {
+		return "", fmt.Errorf("%w: repository and pull request number are required", models.ErrInvalidIntegrationEvent)
+	}
+
+	switch payload.Action {
+	case integrations.GitHubActionOpened, integrations.GitHubActionReadyForReview:
+		if payload.PullRequest.Draft {
+			return integrationResultIgnored, nil
+		}
+		return s.createFromGitHub(ctx, payload, prID)
+
+	case integrations.GitHubActionReopened:
+		_, err := s.prService.Reopen(ctx, prID)
+		if errors.Is(err, models.ErrPullRequestNotFound) && !payload.PullRequest.Draft {
+			return s.createFromGitHub(ctx, payload, prID)
+		}
+		return s.statusResult(integrationResultReopened, err)
+
+	case integrations.GitHubActionClosed:
+		if payload.PullRequest.Merged {
+			_, err := s.prService.Merge(ctx, prID)
+			return s.statusResult(integrationResultMerged, err)
+		}
+		_, err := s.prService.Close(ctx, prID)
+		return s.statusResult(integrationResultClosed, err)
+
+	default:
+		return integrationResultIgnored, nil
+	}
+}
+
+// applyGitHubReviewEvent records submitted approvals and change requests; plain comments are not verdicts.
+func (s *IntegrationService) applyGitHubReviewEvent(
+	ctx context.Context,
+	payload *integrations.GitHubPullRequestEvent,
+	prID string,
+) (string, error) {
+	if payload.Repository.FullName == "" || payload.PullRequest.Number == 0 {
+		return "", fmt.Errorf("%w: repository and pull request number are required", models.ErrInvalidIntegrationEvent)
+	}
+
+	if payload.Action != integrations.GitHubActionSubmitted {
+		return integrationResultIgnored, nil
+	}
+
+	var verdict string
+	switch strings.ToLower(payload.Review.State) {
+	case integrations.GitHubReviewStateApproved:
+		verdict = models.ReviewVerdictApproved
+	case integrations.GitHubReviewStateChangesRequested:
+		verdict = models.ReviewVerdictChangesRequested
+	default:
+		return integrationResultIgnored, nil
+	}
+
+	return s.submitReview(ctx, integrations.ProviderGitHub, []string{payload.Review.User.Login}, prID, verdict)
+}
+
+// submitReview records a provider review as the verdict of the mapped reviewer. Reviews by unmapped users, by
+// users not assigned to the PR and on PRs the service does not track or no longer keeps open are not verdicts
+// of an assignment and are ignored.
+func (s *IntegrationService) submitReview(
+	ctx context.Context,
+	provider string,
+	reviewerLogins []string,
+	prID, verdict string,
+) (string, error) {
+	reviewerID, err := s.resolveUser(provider, reviewerLogins)
+	if errors.Is(err, models.ErrExternalUserNotMapped) {
+		return integrationResultIgnored, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	_, err = s.prService.SubmitReview(ctx, &models.RequestSubmitReview{
+		PullRequestID: prID,
+		ReviewerID:    reviewerID,
+		Verdict:       verdict,
+	})
+	switch {
+	case errors.Is(err, models.ErrPullRequestNotFound), errors.Is(err, models.ErrReviewerNotAssigned),
+		errors.Is(err, models.ErrPullRequestAlreadyMerged), errors.Is(err, models.ErrPullRequestClosed):
+		return integrationResultIgnored, nil
+	case err != nil:
+		return "", err
+	}
+
+	return integrationResultReviewed, nil
+}
+
+// statusResult treats status changes of pull requests the service never tracked as no-ops, and so are closing
+// or reopening a pull request that is already merged: the merge is final.
+func (s *IntegrationService) statusResult(result string, err error) (string, error) {
+	if errors.Is(err, models.ErrPullRequestNotFound) {
+		return integrationResultIgnored, nil
+	}
+	if errors.Is(err, models.ErrPullRequestAlreadyMerged) {
+		return integrationResultAlreadyMerged, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+func (s *IntegrationService) createFromGitHub(
+	ctx context.Context,
+	payload *integrations.GitHubPullRequestEvent,
+	prID string,
+) (string, error) {
+	pr, result, err := s.createPullRequest(
+		ctx,
+		integrations.ProviderGitHub,
+		[]string{payload.PullRequest.User.Login},
+		prID,
+		payload.PullRequest.Title,
+	)
+	if err != nil || pr == nil {
+		return result, err
+	}
+
+	s.requestGitHubReviewers(ctx, payload, pr)
+
+	return result, nil
+}
+
+func (s *IntegrationService) applyGitLabMergeRequestEvent(
+	ctx context.Context,
+	payload *integrations.GitLabMergeRequestEvent,
+	prID string,
+) (string, error) {
+	attributes := payload.ObjectAttributes
+	if payload.Project.PathWithNamespace == "" || attributes.IID == 0 {
+		return "", fmt.Errorf("%w: project path and merge request iid are required", models.ErrInvalidIntegrationEvent)
+	}
+
+	switch attributes.Action {
+	case integrations.GitLabActionOpen:
+		if attributes.IsDraft() {
+			return integrationResultIgnored, nil
+		}
+		return s.createFromGitLab(ctx, payload, prID)
+
+	case integrations.GitLabActionUpdate:
+		// Only leaving draft matters: the merge request becomes ready for review. Other edits are not tracked.
+		if toggled, draft := payload.Changes.DraftChange(); !toggled || draft {
+			return integrationResultIgnored, nil
+		}
+		return s.createFromGitLab(ctx, payload, prID)
+
+	case integrations.GitLabActionReopen:
+		_, err := s.prService.Reopen(ctx, prID)
+		if errors.Is(err, models.ErrPullRequestNotFound) && !attributes.IsDraft() {
+			return s.createFromGitLab(ctx, payload, prID)
+		}
+		return s.statusResult(integrationResultReopened, err)
+
+	case integrations.GitLabActionMerge:
+		_, err := s.prService.Merge(ctx, prID)
+		return s.statusResult(integrationResultMerged, err)
+
+	case integrations.GitLabActionClose:
+		_, err := s.prService.Close(ctx, prID)
+		return s.statusResult(integrationResultClosed, err)
+
+	case integrations.GitLabActionApproved:
+		return s.submitReview(ctx, integrations.ProviderGitLab, payload.UserLogins(), prID, models.ReviewVerdictApproved)
+
+	default:
+		return integrationResultIgnored, nil
+	}
+}
+
+func (s *IntegrationService) createFromGitLab(
+	ctx context.Context,
+	payload *integrations.GitLabMergeRequestEvent,
+	prID string,
+) (string, error) {
+	_, result, err := s.createPullRequest(
+		ctx,
+		integrations.ProviderGitLab,
+		payload.AuthorLogins(),
+		prID,
+		payload.ObjectAttributes.Title,
+	)
+	return result, err
+}
+
+// createPullRequest returns a nil pull request when it already exists, so follow-up actions are skipped on replays.
+func (s *IntegrationService) createPullRequest(
+	ctx context.Context,
+	provider string,
+	authorLogins []string,
+	prID, title string,
+) (*models.PullRequest, string, error) {
+	authorID, err := s.resolveUser(provider, authorLogins)
+	if err != nil {
+		return nil, "", err
+	}
+
+	pr, err := s.prService.Create(ctx, &models.PullRequest{
+		PullRequestID:   prID,
+		PullRequestName: title,
+		AuthorID:        authorID,
+	})
+	if errors.Is(err, models.ErrPullRequestAlreadyExists) {
+		return nil, integrationResultAlreadyExists, nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	return pr, integrationResultCreated, nil
+}
+
+// requestGitHubReviewers mirrors assigned reviewers back to GitHub; failures are logged, the assignment stands.
+func (s *IntegrationService) requestGitHubReviewers(
+	ctx context.Context,
+	payload *integrations.GitHubPullRequestEvent,
+	pr *models.PullRequest,
+) {
+	if s.github == nil || len(pr.AssignedReviewers) == 0 {
+		return
+	}
+
+	reviewerIDs := make([]string, len(pr.AssignedReviewers))
+	for i, reviewer := range pr.AssignedReviewers {
+		reviewerIDs[i] = reviewer.UserID
+	}
+
+	loginsByUser, err := s.integrationRepository.FindLoginsByUserIDs(integrations.ProviderGitHub, reviewerIDs)
+	if err != nil {
+		s.logger.Error("Failed to resolve GitHub logins of reviewers", "error", err, "prID", pr.PullRequestID)
+		return
+	}
+
+	logins := make([]string, 0, len(reviewerIDs))
+	for _, reviewerID := range reviewerIDs {
+		if login, ok := loginsByUser[reviewerID]; ok {
+			logins = append(logins, login)
+		} else {
+			s.logger.Warn("Reviewer has no GitHub login mapping", "prID", pr.PullRequestID, "userID", reviewerID)
+		}
+	}
+
+	if len(logins) == 0 {
+		return
+	}
+
+	if err := s.github.RequestReviewers(ctx, payload.Repository.FullName, payload.PullRequest.Number, logins); err != nil {
+		s.logger.Error("Failed to request reviewers on GitHub", "error", err, "prID", pr.PullRequestID)
+	}
+}
+
+func (s *IntegrationService) resolveUser(provider string, logins []string) (string, error) {
+	if len(logins) == 0 || logins[0] == "" {
+		return "", fmt.Errorf("%w: author login is missing", models.ErrInvalidIntegrationEvent)
+	}
+
+	for _, login := range logins {
+		userID, err := s.integrationRepository.FindUserIDByLogin(provider, login)
+		if err == nil {
+			return userID, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", err
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s user %q", models.ErrExternalUserNotMapped, provider, logins[0])
+}
+
+// integrationActor names the audit actor of provider-driven changes, e.g. "github:octocat".
+func integrationActor(provider, login string) string {
+	if login == "" {
+		return provider
+	}
+	return provider + ":" + login
+}
@@ -0,0 +1,317 @@
+package services
+
+import (
+	"CodeRewievService/internal/events"
+	"CodeRewievService/internal/manifest"
+	"CodeRewievService/internal/models"
+	"CodeRewievService/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// errManifestDryRun rolls back the transaction of a dry run after the diff has been computed.
+var errManifestDryRun = errors.New("manifest dry run")
+
+type ManifestService struct {
+	teamRepository *repository.TeamRepository
+	userRepository *repository.UserRepository
+	prService      *PullRequestService
+	notifications  *NotificationService
+	audit          *AuditService
+	bus            *events.Bus
+	logger         *slog.Logger
+}
+
+func NewManifestService(
+	teamRepository *repository.TeamRepository,
+	userRepository *repository.UserRepository,
+	prService *PullRequestService,
+	notifications *NotificationService,
+	audit *AuditService,
+	bus *events.Bus,
+	logger *slog.Logger,
+) *ManifestService {
+	return &ManifestService{
+		teamRepository: teamRepository,
+		userRepository: userRepository,
+		prService:      prService,
+		notifications:  notifications,
+		audit:          audit,
+		bus:            bus,
+		logger:         logger,
+	}
+}
+
+// ApplyManifest brings the teams listed in the manifest in line with it in one transaction. The manifest
+// describes primary teams: users whose primary team is listed but who are missing from the manifest are
+// deactivated, additional memberships and teams not listed are left untouched. A dry run performs
+// the same changes and rolls them back, so the returned diff is exactly what applying would do.
+func (s *ManifestService) ApplyManifest(
+	ctx context.Context,
+	format string,
+	reader io.Reader,
+	dryRun bool,
+) (*models.ManifestDiff, error) {
+	parsed, err := manifest.Parse(format, reader)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidManifest, err)
+	}
+
+	diff := &models.ManifestDiff{
+		DryRun:            dryRun,
+		NewTeams:          []string{},
+		NewUsers:          []models.ManifestUserChange{},
+		MovedUsers:        []models.ManifestUserChange{},
+		RenamedUsers:      []models.ManifestUserChange{},
+		ReactivatedUsers:  []models.ManifestUserChange{},
+		DeactivatedUsers:  []models.ManifestUserChange{},
+		ReassignedReviews: []models.ReviewReassignment{},
+	}
+
+	err = s.teamRepository.Transaction(func(tx *gorm.DB) error {
+		if err := s.applyInTx(ctx, tx, parsed, diff); err != nil {
+			return err
+		}
+
+		if dryRun {
+			return errManifestDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errManifestDryRun) {
+		return nil, err
+	}
+
+	if !dryRun {
+		s.publishChanges(ctx, diff)
+		s.logger.Info("Teams manifest applied",
+			"newTeams", len(diff.NewTeams),
+			"newUsers", len(diff.NewUsers),
+			"movedUsers", len(diff.MovedUsers),
+			"deactivatedUsers", len(diff.DeactivatedUsers),
+			"reassignedReviews", len(diff.ReassignedReviews),
+		)
+	}
+
+	return diff, nil
+}
+
+func (s *ManifestService) applyInTx(
+	ctx context.Context,
+	tx *gorm.DB,
+	parsed *manifest.Manifest,
+	diff *models.ManifestDiff,
+) error {
+	teamNames := make([]string, len(parsed.Teams))
+	var userIDs []string
+	listed := make(map[string]bool)
+
+	for i, team := range parsed.Teams {
+		teamNames[i] = team.Name
+		for _, member := range team.Members {
+			userIDs = append(userIDs, member.UserID)
+			listed[member.UserID] = true
+		}
+	}
+
+	if err := s.createMissingTeams(tx, teamNames, diff); err != nil {
+		return err
+	}
+
+	existingUsers, err := s.userRepository.FindByIDsInTx(tx, userIDs)
+	if err != nil {
+		return err
+	}
+
+	usersByID := make(map[string]*models.User, len(existingUsers))
+	for i := range existingUsers {
+		usersByID[existingUsers[i].UserID] = &existingUsers[i]
+	}
+
+	var released []string
+	var deactivated []*models.User
+
+	for _, team := range parsed.Teams {
+		for _, member := range team.Members {
+			user, ok := usersByID[member.UserID]
+			if !ok {
+				if err := s.createUser(tx, team.Name, member, diff); err != nil {
+					return err
+				}
+				continue
+			}
+
+			change, err := s.syncUser(tx, user, team.Name, member, diff)
+			if err != nil {
+				return err
+			}
+
+			if change.moved || change.deactivated {
+				released = append(released, user.UserID)
+			}
+			if change.deactivated {
+				deactivated = append(deactivated, user)
+			}
+		}
+	}
+
+	currentMembers, err := s.userRepository.FindByTeamsInTx(tx, teamNames)
+	if err != nil {
+		return err
+	}
+
+	for i := range currentMembers {
+		user := &currentMembers[i]
+		if listed[user.UserID] || !user.IsActive {
+			continue
+		}
+
+		user.IsActive = false
+		if err := s.userRepository.UpdateInTx(tx, user); err != nil {
+			return err
+		}
+
+		diff.DeactivatedUsers = append(diff.DeactivatedUsers, toManifestUserChange(user))
+		released = append(released, user.UserID)
+		deactivated = append(deactivated, user)
+	}
+
+	if diff.ReassignedReviews, err = s.prService.ReassignReviewsInTx(ctx, tx, released); err != nil {
+		return err
+	}
+	if diff.ReassignedReviews == nil {
+		diff.ReassignedReviews = []models.ReviewReassignment{}
+	}
+
+	for _, user := range deactivated {
+		if err := s.notifications.EnqueueInTx(tx, userDeactivatedEvent(user), user.UserID); err != nil {
+			return err
+		}
+	}
+
+	return s.audit.RecordInTx(ctx, tx, models.AuditTeamManifestApply, models.AuditEntityTeam,
+		strings.Join(teamNames, ","), nil, diff)
+}
+
+func (s *ManifestService) publishChanges(ctx context.Context, diff *models.ManifestDiff) {
+	published := make([]events.Event, 0, len(diff.DeactivatedUsers)+len(diff.ReassignedReviews))
+
+	for _, user := range diff.DeactivatedUsers {
+		published = append(published, events.UserDeactivated{UserID: user.UserID, TeamName: user.TeamName})
+	}
+
+	s.bus.Publish(ctx, append(published, reviewerReplacedEvents(diff.ReassignedReviews)...)...)
+}
+
+func (s *ManifestService) createMissingTeams(tx *gorm.DB, teamNames []string, diff *models.ManifestDiff) error {
+	existing, err := s.teamRepository.FindExistingNamesInTx(tx, teamNames)
+	if err != nil {
+		return err
+	}
+
+	exists := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		exists[name] = true
+	}
+
+	for _, name := range teamNames {
+		if exists[name] {
+			continue
+		}
+
+		if err := s.teamRepository.CreateInTx(tx, &models.Team{TeamName: name}); err != nil {
+			return err
+		}
+		diff.NewTeams = append(diff.NewTeams, name)
+	}
+
+	return nil
+}
+
+func (s *ManifestService) createUser(tx *gorm.DB, teamName string, member manifest.Member, diff *models.ManifestDiff) error {
+	user := &models.User{
+		UserID:   member.UserID,
+		Username: member.Username,
+		TeamName: teamName,
+		IsActive: member.Active(),
+	}
+
+	if err := s.userRepository.CreateInTx(tx, user); err != nil {
+		return err
+	}
+
+	diff.NewUsers = append(diff.NewUsers, toManifestUserChange(user))
+	return nil
+}
+
+type manifestUserSync struct {
+	moved       bool
+	deactivated bool
+}
+
+// syncUser updates an existing user to match the manifest; moved and deactivated users need their open
+// reviews re-checked.
+func (s *ManifestService) syncUser(
+	tx *gorm.DB,
+	user *models.User,
+	teamName string,
+	member manifest.Member,
+	diff *models.ManifestDiff,
+) (manifestUserSync, error) {
+	previous := *user
+	user.TeamName = teamName
+	user.Username = member.Username
+	user.IsActive = member.Active()
+
+	if *user == previous {
+		return manifestUserSync{}, nil
+	}
+
+	if err := s.userRepository.UpdateInTx(tx, user); err != nil {
+		return manifestUserSync{}, err
+	}
+
+	var sync manifestUserSync
+	change := toManifestUserChange(user)
+
+	if previous.TeamName != user.TeamName {
+		if err := s.teamRepository.MoveMemberInTx(tx, user.UserID, previous.TeamName, user.TeamName, true); err != nil {
+			return manifestUserSync{}, err
+		}
+
+		moved := change
+		moved.PreviousTeam = previous.TeamName
+		diff.MovedUsers = append(diff.MovedUsers, moved)
+		sync.moved = true
+	}
+
+	if previous.Username != user.Username {
+		renamed := change
+		renamed.PreviousUsername = previous.Username
+		diff.RenamedUsers = append(diff.RenamedUsers, renamed)
+	}
+
+	switch {
+	case previous.IsActive && !user.IsActive:
+		diff.DeactivatedUsers = append(diff.DeactivatedUsers, change)
+		sync.deactivated = true
+	case !previous.IsActive && user.IsActive:
+		diff.ReactivatedUsers = append(diff.ReactivatedUsers, change)
+	}
+
+	return sync, nil
+}
+
+func toManifestUserChange(user *models.User) models.ManifestUserChange {
+	return models.ManifestUserChange{
+		UserID:   user.UserID,
+		Username: user.Username,
+		TeamName: user.TeamName,
+	}
+}
@@ -0,0 +1,325 @@
+package services
+
+import (
+	"CodeRewievService/internal/events"
+	"CodeRewievService/internal/models"
+	"CodeRewievService/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+type MembershipService struct {
+	teamRepository *repository.TeamRepository
+	userRepository *repository.UserRepository
+	prService      *PullRequestService
+	audit          *AuditService
+	bus            *events.Bus
+}
+
+func NewMembershipService(
+	teamRepository *repository.TeamRepository,
+	userRepository *repository.UserRepository,
+	prService *PullRequestService,
+	audit *AuditService,
+	bus *events.Bus,
+) *MembershipService {
+	return &MembershipService{
+		teamRepository: teamRepository,
+		userRepository: userRepository,
+		prService:      prService,
+		audit:          audit,
+		bus:            bus,
+	}
+}
+
+// AddMember creates a user in the team or gives an existing user an additional membership. The team becomes
+// the user's primary one on request or when they have none yet. An existing user's activity is not changed here
+// because deactivation has to reassign their reviews.
+func (s *MembershipService) AddMember(ctx context.Context, req *models.RequestAddTeamMember) (*models.MembershipChange, error) {
+	if req.TeamName == "" || req.UserID == "" {
+		return nil, fmt.Errorf("%w: teamName and userId are required", models.ErrInvalidMembershipChange)
+	}
+
+	change := &models.MembershipChange{ReassignedReviews: []models.ReviewReassignment{}}
+
+	err := s.userRepository.Transaction(func(tx *gorm.DB) error {
+		if err := s.checkTeamExistsInTx(tx, req.TeamName); err != nil {
+			return err
+		}
+
+		user, err := s.userRepository.FindByIDForUpdateInTx(tx, req.UserID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return s.createMemberInTx(ctx, tx, req, change)
+		}
+		if err != nil {
+			return err
+		}
+
+		teamNames, err := s.teamRepository.FindTeamNamesByUserInTx(tx, user.UserID)
+		if err != nil {
+			return err
+		}
+
+		if slices.Contains(teamNames, req.TeamName) {
+			return models.ErrAlreadyTeamMember
+		}
+		if req.IsActive != nil && *req.IsActive != user.IsActive {
+			return fmt.Errorf("%w: isActive of an existing user is changed by /users/bulkSetIsActive", models.ErrInvalidMembershipChange)
+		}
+
+		before := *user
+		primary := req.Primary || user.TeamName == ""
+		if err := s.teamRepository.AddMemberInTx(tx, user.UserID, req.TeamName, primary); err != nil {
+			return err
+		}
+
+		if primary {
+			user.TeamName = req.TeamName
+		}
+		if req.Username != "" {
+			user.Username = req.Username
+		}
+
+		if err := s.userRepository.UpdateInTx(tx, user); err != nil {
+			return err
+		}
+
+		change.User = *user
+		if change.Teams, err = s.teamRepository.FindMembershipsByUserInTx(tx, user.UserID); err != nil {
+			return err
+		}
+
+		return s.audit.RecordInTx(ctx, tx, models.AuditTeamAddMember, models.AuditEntityUser, user.UserID, before, change)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
+
+// RemoveMember drops one membership of the user; removing the primary team leaves the user without one. The
+// user record stays because it is referenced by authored PRs and review history.
+func (s *MembershipService) RemoveMember(
+	ctx context.Context,
+	req *models.RequestRemoveTeamMember,
+) (*models.MembershipChange, error) {
+	if req.TeamName == "" || req.UserID == "" {
+		return nil, fmt.Errorf("%w: teamName and userId are required", models.ErrInvalidMembershipChange)
+	}
+
+	policy, err := s.normalizePolicy(req.Policy)
+	if err != nil {
+		return nil, err
+	}
+
+	change := &models.MembershipChange{PreviousTeam: req.TeamName, Policy: policy}
+
+	err = s.userRepository.Transaction(func(tx *gorm.DB) error {
+		user, err := s.findMemberInTx(tx, req.UserID, req.TeamName)
+		if err != nil {
+			return err
+		}
+
+		before := *user
+		if err := s.teamRepository.RemoveMemberInTx(tx, user.UserID, req.TeamName); err != nil {
+			return err
+		}
+
+		if user.TeamName == req.TeamName {
+			user.TeamName = ""
+		}
+
+		if policy == models.MembershipPolicyReassign {
+			if change.ReassignedReviews, err = s.prService.ReassignReviewsInTx(ctx, tx, []string{user.UserID}); err != nil {
+				return err
+			}
+		}
+
+		change.User = *user
+		if change.Teams, err = s.teamRepository.FindMembershipsByUserInTx(tx, user.UserID); err != nil {
+			return err
+		}
+
+		return s.audit.RecordInTx(ctx, tx, models.AuditTeamRemoveMember, models.AuditEntityUser, user.UserID, before, change)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.publish(ctx, change), nil
+}
+
+// MoveMember replaces one membership of the user, the primary one unless fromTeam is given, with the target
+// team, which inherits the primary flag. With the reassign policy the user's reviews they are no longer eligible
+// for and reviewers of the user's own open PRs outside the new pool are handed to eligible members.
+func (s *MembershipService) MoveMember(
+	ctx context.Context,
+	req *models.RequestMoveTeamMember,
+) (*models.MembershipChange, error) {
+	if req.ToTeam == "" || req.UserID == "" {
+		return nil, fmt.Errorf("%w: toTeam and userId are required", models.ErrInvalidMembershipChange)
+	}
+
+	policy, err := s.normalizePolicy(req.Policy)
+	if err != nil {
+		return nil, err
+	}
+
+	change := &models.MembershipChange{Policy: policy}
+
+	err = s.userRepository.Transaction(func(tx *gorm.DB) error {
+		if err := s.checkTeamExistsInTx(tx, req.ToTeam); err != nil {
+			return err
+		}
+
+		user, err := s.findMemberInTx(tx, req.UserID, req.FromTeam)
+		if err != nil {
+			return err
+		}
+
+		from := req.FromTeam
+		if from == "" {
+			from = user.TeamName
+		}
+		if from == "" {
+			return models.ErrNotTeamMember
+		}
+		if from == req.ToTeam {
+			return models.ErrAlreadyTeamMember
+		}
+
+		before := *user
+		change.PreviousTeam = from
+		primary := user.TeamName == from
+		if err := s.teamRepository.MoveMemberInTx(tx, user.UserID, from, req.ToTeam, primary); err != nil {
+			return err
+		}
+
+		if primary {
+			user.TeamName = req.ToTeam
+		}
+
+		if policy == models.MembershipPolicyReassign {
+			reviews, err := s.prService.ReassignReviewsInTx(ctx, tx, []string{user.UserID})
+			if err != nil {
+				return err
+			}
+
+			authored, err := s.prService.ReassignAuthoredReviewsInTx(ctx, tx, user.UserID)
+			if err != nil {
+				return err
+			}
+
+			change.ReassignedReviews = append(reviews, authored...)
+		}
+
+		change.User = *user
+		if change.Teams, err = s.teamRepository.FindMembershipsByUserInTx(tx, user.UserID); err != nil {
+			return err
+		}
+
+		return s.audit.RecordInTx(ctx, tx, models.AuditTeamMoveMember, models.AuditEntityUser, user.UserID, before, change)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.publish(ctx, change), nil
+}
+
+func (s *MembershipService) createMemberInTx(
+	ctx context.Context,
+	tx *gorm.DB,
+	req *models.RequestAddTeamMember,
+	change *models.MembershipChange,
+) error {
+	if req.Username == "" {
+		return fmt.Errorf("%w: username is required for a new user", models.ErrInvalidMembershipChange)
+	}
+
+	user := &models.User{
+		UserID:   req.UserID,
+		Username: req.Username,
+		TeamName: req.TeamName,
+		IsActive: req.IsActive == nil || *req.IsActive,
+	}
+
+	if err := s.userRepository.CreateInTx(tx, user); err != nil {
+		return err
+	}
+
+	var err error
+	change.User = *user
+	if change.Teams, err = s.teamRepository.FindMembershipsByUserInTx(tx, user.UserID); err != nil {
+		return err
+	}
+
+	return s.audit.RecordInTx(ctx, tx, models.AuditTeamAddMember, models.AuditEntityUser, user.UserID, nil, change)
+}
+
+// findMemberInTx locks the user; an empty teamName skips the membership check.
+func (s *MembershipService) findMemberInTx(tx *gorm.DB, userID, teamName string) (*models.User, error) {
+	user, err := s.userRepository.FindByIDForUpdateInTx(tx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, models.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if teamName == "" {
+		return user, nil
+	}
+
+	teamNames, err := s.teamRepository.FindTeamNamesByUserInTx(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(teamNames, teamName) {
+		return nil, models.ErrNotTeamMember
+	}
+
+	return user, nil
+}
+
+// checkTeamExistsInTx locks the team so that it cannot be archived while members join it.
+func (s *MembershipService) checkTeamExistsInTx(tx *gorm.DB, teamName string) error {
+	team, err := s.teamRepository.FindByNameForUpdateInTx(tx, teamName)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.ErrTeamNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if team.ArchivedAt != nil {
+		return models.ErrTeamArchived
+	}
+	return nil
+}
+
+func (s *MembershipService) normalizePolicy(policy string) (string, error) {
+	if policy == "" {
+		return models.MembershipPolicyReassign, nil
+	}
+
+	if !models.IsKnownMembershipPolicy(policy) {
+		return "", fmt.Errorf("%w: policy must be keep or reassign", models.ErrInvalidMembershipChange)
+	}
+	return policy, nil
+}
+
+func (s *MembershipService) publish(ctx context.Context, change *models.MembershipChange) *models.MembershipChange {
+	if change.ReassignedReviews == nil {
+		change.ReassignedReviews = []models.ReviewReassignment{}
+	}
+
+	s.bus.Publish(ctx, reviewerReplacedEvents(change.ReassignedReviews)...)
+	return change
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"CodeRewievService/internal/models"
+	"fmt"
+	"time"
+)
+
+func reviewerAssignedEvent(pr *models.PullRequest, reviewerID string) models.NotificationEvent {
+	return models.NotificationEvent{
+		Type:    models.EventReviewerAssigned,
+		Subject: fmt.Sprintf("Review requested: %s", pr.PullRequestName),
+		Text:    fmt.Sprintf("You were assigned to review pull request %s (%s) by %s.", pr.PullRequestID, pr.PullRequestName, pr.AuthorID),
+		Data: map[string]interface{}{
+			"pullRequestId":   pr.PullRequestID,
+			"pullRequestName": pr.PullRequestName,
+			"authorId":        pr.AuthorID,
+			"reviewerId":      reviewerID,
+		},
+	}
+}
+
+func reviewerUnassignedEvent(pr *models.PullRequest, reviewerID, replacedBy string) models.NotificationEvent {
+	text := fmt.Sprintf("Your review of pull request %s (%s) was reassigned to %s.", pr.PullRequestID, pr.PullRequestName, replacedBy)
+	if replacedBy == "" {
+		text = fmt.Sprintf("Your review of pull request %s (%s) was withdrawn.", pr.PullRequestID, pr.PullRequestName)
+	}
+
+	return models.NotificationEvent{
+		Type:    models.EventReviewerUnassigned,
+		Subject: fmt.Sprintf("Review reassigned: %s", pr.PullRequestName),
+		Text:    text,
+		Data: map[string]interface{}{
+			"pullRequestId":   pr.PullRequestID,
+			"pullRequestName": pr.PullRequestName,
+			"reviewerId":      reviewerID,
+			"replacedBy":      replacedBy,
+		},
+	}
+}
+
+func pullRequestMergedEvent(pr *models.PullRequest) models.NotificationEvent {
+	return models.NotificationEvent{
+		Type:    models.EventPullRequestMerged,
+		Subject: fmt.Sprintf("Merged: %s", pr.PullRequestName),
+		Text:    fmt.Sprintf("Pull request %s (%s) you were reviewing has been merged.", pr.PullRequestID, pr.PullRequestName),
+		Data: map[string]interface{}{
+			"pullRequestId":   pr.PullRequestID,
+			"pullRequestName": pr.PullRequestName,
+			"authorId":        pr.AuthorID,
+			"mergedAt":        pr.MergedAt,
+		},
+	}
+}
+
+func userDeactivatedEvent(user *models.User) models.NotificationEvent {
+	return models.NotificationEvent{
+		Type:    models.EventUserDeactivated,
+		Subject: "Your account was deactivated",
+		Text:    fmt.Sprintf("User %s was deactivated and will no longer be assigned as a reviewer.", user.UserID),
+		Data: map[string]interface{}{
+			"userId":   user.UserID,
+			"teamName": user.TeamName,
+		},
+	}
+}
+
+func teamDeactivatedEvent(teamName string) models.NotificationEvent {
+	return models.NotificationEvent{
+		Type:    models.EventTeamDeactivated,
+		Subject: fmt.Sprintf("Team %s was deactivated", teamName),
+		Text:    fmt.Sprintf("All members of team %s were deactivated and removed from open reviews.", teamName),
+		Data: map[string]interface{}{
+			"teamName": teamName,
+		},
+	}
+}
+
+func teamArchivedEvent(teamName string) models.NotificationEvent {
+	return models.NotificationEvent{
+		Type:    models.EventTeamArchived,
+		Subject: fmt.Sprintf("Team %s was archived", teamName),
+		Text:    fmt.Sprintf("Team %s was archived; you were deactivated as you are not a member of another team.", teamName),
+		Data: map[string]interface{}{
+			"teamName": teamName,
+		},
+	}
+}
+
+func reviewSLABreachedEvent(review *models.PendingReview, deadline time.Time) models.NotificationEvent {
+	return models.NotificationEvent{
+		Type:    models.EventReviewSLABreached,
+		Subject: fmt.Sprintf("Review overdue: %s", review.PullRequestID),
+		Text:    fmt.Sprintf("Your review of pull request %s was due at %s.", review.PullRequestID, deadline.Format(time.RFC3339)),
+		Data: map[string]interface{}{
+			"pullRequestId": review.PullRequestID,
+			"reviewerId":    review.UserID,
+			"teamName":      review.TeamName,
+			"assignedAt":    review.AssignedAt,
+			"deadlineAt":    deadline,
+		},
+	}
+}
@@ -0,0 +1,307 @@
+package services
+
+import (
+	"CodeRewievService/internal/models"
+	"CodeRewievService/internal/notifications"
+	"CodeRewievService/internal/repository"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	notificationDispatchBatchSize = 50
+	maxNotificationErrorLength    = 1000
+
+	// notificationLease must outlast sending a whole batch; an unfinished lease is retried after it expires.
+	notificationLease = 10 * time.Minute
+)
+
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 8,
+		BaseDelay:   30 * time.Second,
+		MaxDelay:    time.Hour,
+	}
+}
+
+// NextAttempt returns the exponential backoff delay after the given number of failed attempts.
+func (p RetryPolicy) NextAttempt(attempts int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempts && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return delay
+}
+
+type NotificationService struct {
+	notificationRepository *repository.NotificationRepository
+	userRepository         *repository.UserRepository
+	senders                *notifications.Registry
+	retryPolicy            RetryPolicy
+	logger                 *slog.Logger
+}
+
+func NewNotificationService(
+	notificationRepository *repository.NotificationRepository,
+	userRepository *repository.UserRepository,
+	senders *notifications.Registry,
+	retryPolicy RetryPolicy,
+	logger *slog.Logger,
+) *NotificationService {
+	return &NotificationService{
+		notificationRepository: notificationRepository,
+		userRepository:         userRepository,
+		senders:                senders,
+		retryPolicy:            retryPolicy,
+		logger:                 logger,
+	}
+}
+
+func (s *NotificationService) GetPreferences(userID string) (*models.UserNotificationPreferences, error) {
+	if err := s.validateUserExists(userID); err != nil {
+		return nil, err
+	}
+
+	preferences, err := s.notificationRepository.GetPreferences(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.UserNotificationPreferences{
+		UserID:   userID,
+		Channels: preferences,
+	}, nil
+}
+
+func (s *NotificationService) SetPreferences(
+	req *models.RequestSetNotificationPreferences,
+) (*models.UserNotificationPreferences, error) {
+	if err := s.validatePreferences(req); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateUserExists(req.UserID); err != nil {
+		return nil, err
+	}
+
+	preferences := make([]models.NotificationPreference, len(req.Channels))
+	for i, channel := range req.Channels {
+		preferences[i] = models.NotificationPreference{
+			UserID:  req.UserID,
+			Channel: channel.Channel,
+			Address: channel.Address,
+			Enabled: channel.Enabled,
+		}
+	}
+
+	if err := s.notificationRepository.ReplacePreferences(req.UserID, preferences); err != nil {
+		return nil, err
+	}
+
+	return s.GetPreferences(req.UserID)
+}
+
+// EnqueueInTx writes one outbox row per enabled channel of every recipient as part of the caller's transaction.
+func (s *NotificationService) EnqueueInTx(tx *gorm.DB, event models.NotificationEvent, recipientIDs ...string) error {
+	if len(recipientIDs) == 0 {
+		return nil
+	}
+
+	preferences, err := s.notificationRepository.GetEnabledPreferencesInTx(tx, recipientIDs)
+	if err != nil {
+		return err
+	}
+
+	if len(preferences) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(event.Data)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification payload: %w", err)
+	}
+
+	now := time.Now()
+	outbox := make([]models.Notification, len(preferences))
+	for i, preference := range preferences {
+		outbox[i] = models.Notification{
+			EventType:     event.Type,
+			RecipientID:   preference.UserID,
+			Channel:       preference.Channel,
+			Address:       preference.Address,
+			Subject:       event.Subject,
+			Body:          event.Text,
+			Payload:       payload,
+			Status:        models.NotificationStatusPending,
+			NextAttemptAt: now,
+		}
+	}
+
+	return s.notificationRepository.EnqueueInTx(tx, outbox)
+}
+
+// EnqueueEachInTx is EnqueueInTx for events addressed to one recipient each, looking up preferences once.
+func (s *NotificationService) EnqueueEachInTx(tx *gorm.DB, eventsByRecipient map[string]models.NotificationEvent) error {
+	if len(eventsByRecipient) == 0 {
+		return nil
+	}
+
+	recipientIDs := make([]string, 0, len(eventsByRecipient))
+	for recipientID := range eventsByRecipient {
+		recipientIDs = append(recipientIDs, recipientID)
+	}
+
+	preferences, err := s.notificationRepository.GetEnabledPreferencesInTx(tx, recipientIDs)
+	if err != nil {
+		return err
+	}
+
+	if len(preferences) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	outbox := make([]models.Notification, len(preferences))
+	for i, preference := range preferences {
+		event := eventsByRecipient[preference.UserID]
+
+		payload, err := json.Marshal(event.Data)
+		if err != nil {
+			return fmt.Errorf("failed to encode notification payload: %w", err)
+		}
+
+		outbox[i] = models.Notification{
+			EventType:     event.Type,
+			RecipientID:   preference.UserID,
+			Channel:       preference.Channel,
+			Address:       preference.Address,
+			Subject:       event.Subject,
+			Body:          event.Text,
+			Payload:       payload,
+			Status:        models.NotificationStatusPending,
+			NextAttemptAt: now,
+		}
+	}
+
+	return s.notificationRepository.EnqueueInTx(tx, outbox)
+}
+
+func (s *NotificationService) Enqueue(event models.NotificationEvent, recipientIDs ...string) error {
+	return s.notificationRepository.Transaction(func(tx *gorm.DB) error {
+		return s.EnqueueInTx(tx, event, recipientIDs...)
+	})
+}
+
+// DispatchPending sends leased notifications outside any transaction and records each result separately, so a
+// failure later in the batch cannot roll back a delivery that has already happened.
+func (s *NotificationService) DispatchPending(ctx context.Context, now time.Time) (int, error) {
+	pending, err := s.notificationRepository.LeasePending(now, notificationLease, notificationDispatchBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for i := range pending {
+		notification := &pending[i]
+		if s.deliver(ctx, notification, now) {
+			sent++
+		}
+
+		if err := s.notificationRepository.UpdateDelivery(notification); err != nil {
+			return sent, err
+		}
+	}
+
+	return sent, nil
+}
+
+func (s *NotificationService) deliver(ctx context.Context, notification *models.Notification, now time.Time) bool {
+	notification.Attempts++
+
+	err := s.senders.Send(ctx, notification.Channel, notification.Address, notifications.Message{
+		EventType: notification.EventType,
+		Subject:   notification.Subject,
+		Text:      notification.Body,
+		Payload:   notification.Payload,
+	})
+
+	if err == nil {
+		notification.Status = models.NotificationStatusSent
+		notification.LastError = ""
+		notification.SentAt = &now
+		return true
+	}
+
+	notification.LastError = truncate(err.Error(), maxNotificationErrorLength)
+
+	if errors.Is(err, notifications.ErrNoSender) || notification.Attempts >= s.retryPolicy.MaxAttempts {
+		notification.Status = models.NotificationStatusFailed
+		s.logger.Error("Notification delivery failed permanently",
+			"error", err,
+			"notificationID", notification.ID,
+			"channel", notification.Channel,
+			"attempts", notification.Attempts,
+		)
+		return false
+	}
+
+	notification.NextAttemptAt = now.Add(s.retryPolicy.NextAttempt(notification.Attempts))
+	s.logger.Warn("Notification delivery failed, will retry",
+		"error", err,
+		"notificationID", notification.ID,
+		"channel", notification.Channel,
+		"nextAttemptAt", notification.NextAttemptAt,
+	)
+	return false
+}
+
+func (s *NotificationService) validatePreferences(req *models.RequestSetNotificationPreferences) error {
+	if req == nil || req.UserID == "" {
+		return errors.New("user_id cannot be empty")
+	}
+
+	seen := make(map[string]bool, len(req.Channels))
+	for _, channel := range req.Channels {
+		if !models.IsValidNotificationChannel(channel.Channel) || channel.Address == "" || seen[channel.Channel] {
+			return models.ErrInvalidNotificationPrefs
+		}
+		seen[channel.Channel] = true
+	}
+
+	return nil
+}
+
+func (s *NotificationService) validateUserExists(userID string) error {
+	if userID == "" {
+		return errors.New("user_id cannot be empty")
+	}
+
+	_, err := s.userRepository.FindByID(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.ErrUserNotFound
+	}
+	return err
+}
+
+func truncate(value string, maxLength int) string {
+	if len(value) <= maxLength {
+		return value
+	}
+	return value[:maxLength]
+}
@@ -1,10 +1,15 @@
 package services
 
 import (
+	"CodeRewievService/internal/audit"
+	"CodeRewievService/internal/events"
 	"CodeRewievService/internal/models"
 	"CodeRewievService/internal/repository"
+	"context"
 	"errors"
+	"fmt"
 	"math/rand"
+	"slices"
 	"time"
 
 	"gorm.io/gorm"
@@ -15,22 +20,37 @@ const defaultReviewersCount = 2
 type PullRequestService struct {
 	prRepository   *repository.PullRequestRepository
 	userRepository *repository.UserRepository
+	teamRepository *repository.TeamRepository
+	calendars      *CalendarService
+	notifications  *NotificationService
+	audit          *AuditService
+	bus            *events.Bus
 	randomizer     *rand.Rand
 }
 
 func NewPullRequestService(
 	prRepository *repository.PullRequestRepository,
 	userRepository *repository.UserRepository,
+	teamRepository *repository.TeamRepository,
+	calendars *CalendarService,
+	notifications *NotificationService,
+	audit *AuditService,
+	bus *events.Bus,
 ) *PullRequestService {
 	return &PullRequestService{
 		prRepository:   prRepository,
 		userRepository: userRepository,
+		teamRepository: teamRepository,
+		calendars:      calendars,
+		notifications:  notifications,
+		audit:          audit,
+		bus:            bus,
 		//nolint:gosec // math/rand достаточно для балансировки нагрузки
 		randomizer: rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
 
-func (s *PullRequestService) Create(pr *models.PullRequest) (*models.PullRequest, error) {
+func (s *PullRequestService) Create(ctx context.Context, pr *models.PullRequest) (*models.PullRequest, error) {
 	if err := s.validatePullRequestInput(pr); err != nil {
 		return nil, err
 	}
@@ -39,39 +59,56 @@ func (s *PullRequestService) Create(pr *models.PullRequest) (*models.PullRequest
 		return nil, err
 	}
 
-	author, err := s.validateAuthor(pr.AuthorID)
-	if err != nil {
+	if _, err := s.validateAuthor(pr.AuthorID); err != nil {
 		return nil, err
 	}
 
-	teamMembers, err := s.userRepository.GetActiveTeamMembers(author.TeamName, pr.AuthorID)
-	if err != nil {
+	if err := s.validateRepositoryTeam(pr.TeamName); err != nil {
 		return nil, err
 	}
 
-	assignedReviewers := s.selectRandomReviewers(teamMembers, defaultReviewersCount, pr.PullRequestID)
-
 	newPR := models.PullRequest{
-		PullRequestID:     pr.PullRequestID,
-		PullRequestName:   pr.PullRequestName,
-		AuthorID:          pr.AuthorID,
-		Status:            "OPEN",
-		AssignedReviewers: assignedReviewers,
+		PullRequestID:   pr.PullRequestID,
+		PullRequestName: pr.PullRequestName,
+		AuthorID:        pr.AuthorID,
+		TeamName:        pr.TeamName,
+		Status:          "OPEN",
 	}
 
-	if err := s.createPRInTransaction(&newPR); err != nil {
+	if err := s.createPRInTransaction(ctx, &newPR); err != nil {
+		return nil, err
+	}
+
+	createdPR, err := s.prRepository.FindByIDWithRelations(pr.PullRequestID)
+	if err != nil {
 		return nil, err
 	}
 
-	return s.prRepository.FindByIDWithRelations(pr.PullRequestID)
+	s.bus.Publish(ctx, prCreatedEvents(createdPR)...)
+
+	return s.withBusinessAge(createdPR)
 }
 
-func (s *PullRequestService) Merge(prID string) (*models.PullRequest, error) {
+// prCreatedEvents announces a new PR and each of its initial reviewers.
+func prCreatedEvents(pr *models.PullRequest) []events.Event {
+	published := []events.Event{events.PRCreated{PullRequest: pr.ToResponse()}}
+	for _, reviewer := range pr.AssignedReviewers {
+		published = append(published, events.ReviewerAssigned{
+			PullRequestID: pr.PullRequestID,
+			ReviewerID:    reviewer.UserID,
+			Reason:        models.ReviewerChangeInitial,
+		})
+	}
+
+	return published
+}
+
+func (s *PullRequestService) Merge(ctx context.Context, prID string) (*models.PullRequest, error) {
 	if prID == "" {
 		return nil, errors.New("pull_request_id cannot be empty")
 	}
 
-	pr, err := s.prRepository.FindByID(prID)
+	pr, err := s.prRepository.FindByIDWithReviewers(prID)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, models.ErrPullRequestNotFound
 	}
@@ -81,21 +118,55 @@ func (s *PullRequestService) Merge(prID string) (*models.PullRequest, error) {
 	}
 
 	if pr.Status == "MERGED" {
-		return pr, nil
+		return s.withBusinessAge(pr)
 	}
 
+	if pr.Status == "CLOSED" {
+		return nil, models.ErrPullRequestClosed
+	}
+
+	before := pr.ToSnapshot()
 	now := time.Now()
 	pr.Status = "MERGED"
 	pr.MergedAt = &now
+	merged := events.PRMerged{PullRequest: pr.ToResponse(), MergedAt: now}
+
+	err = s.prRepository.Transaction(func(tx *gorm.DB) error {
+		if err := s.prRepository.UpdateInTx(tx, pr); err != nil {
+			return err
+		}
+
+		if err := s.audit.RecordInTx(ctx, tx, models.AuditPullRequestMerge, models.AuditEntityPullRequest,
+			pr.PullRequestID, before, pr.ToSnapshot()); err != nil {
+			return err
+		}
 
-	if err := s.prRepository.Update(pr); err != nil {
+		if err := s.prRepository.CreateEventsInTx(tx, []models.PullRequestEvent{
+			newPullRequestEvent(ctx, pr.PullRequestID, models.PullRequestEventMerged),
+		}); err != nil {
+			return err
+		}
+
+		if err := s.bus.PublishInTx(ctx, tx, merged); err != nil {
+			return err
+		}
+
+		return s.notifications.EnqueueInTx(tx, pullRequestMergedEvent(pr), s.extractReviewerIDs(pr.AssignedReviewers)...)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return pr, nil
+	s.bus.Publish(ctx, merged)
+
+	return s.withBusinessAge(pr)
 }
 
-func (s *PullRequestService) Reassign(prID string, oldUserID string) (*models.PullRequest, string, error) {
+// Reassign replaces a reviewer with another member of the PR's reviewer pool; reason ends up in the PR timeline.
+func (s *PullRequestService) Reassign(
+	ctx context.Context,
+	prID, oldUserID, reason string,
+) (*models.PullRequest, string, error) {
 	if err := s.validateReassignInput(prID, oldUserID); err != nil {
 		return nil, "", err
 	}
@@ -113,11 +184,46 @@ func (s *PullRequestService) Reassign(prID string, oldUserID string) (*models.Pu
 		return nil, "", models.ErrPullRequestAlreadyMerged
 	}
 
+	if pr.Status == "CLOSED" {
+		return nil, "", models.ErrPullRequestClosed
+	}
+
 	if err := s.validateReviewerAssigned(pr, oldUserID); err != nil {
 		return nil, "", err
 	}
 
-	oldReviewer, err := s.userRepository.FindByID(oldUserID)
+	newReviewerID, err := s.performReassignment(ctx, pr, oldUserID, reason)
+	if err != nil {
+		return nil, "", err
+	}
+
+	updatedPR, err := s.prRepository.FindByIDWithRelations(prID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	updatedPR, err = s.withBusinessAge(updatedPR)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return updatedPR, newReviewerID, nil
+}
+
+func (s *PullRequestService) Close(ctx context.Context, prID string) (*models.PullRequest, error) {
+	return s.changeStatus(ctx, models.AuditPullRequestClose, models.PullRequestEventClosed, prID, "OPEN", "CLOSED")
+}
+
+func (s *PullRequestService) Reopen(ctx context.Context, prID string) (*models.PullRequest, error) {
+	return s.changeStatus(ctx, models.AuditPullRequestReopen, models.PullRequestEventReopened, prID, "CLOSED", "OPEN")
+}
+
+func (s *PullRequestService) AddReviewer(ctx context.Context, prID, reason string) (*models.PullRequest, string, error) {
+	if prID == "" {
+		return nil, "", errors.New("pull_request_id cannot be empty")
+	}
+
+	pr, err := s.prRepository.FindByIDWithRelations(prID)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, "", models.ErrPullRequestNotFound
 	}
@@ -126,17 +232,220 @@ func (s *PullRequestService) Reassign(prID string, oldUserID string) (*models.Pu
 		return nil, "", err
 	}
 
-	newReviewerID, err := s.performReassignment(pr, oldReviewer, oldUserID)
+	if pr.Status == "MERGED" {
+		return nil, "", models.ErrPullRequestAlreadyMerged
+	}
+
+	if pr.Status == "CLOSED" {
+		return nil, "", models.ErrPullRequestClosed
+	}
+
+	var newReviewer *models.ReviewerCandidate
+	err = s.prRepository.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if newReviewer, err = s.pickReviewerInTx(tx, pr); err != nil {
+			return err
+		}
+
+		if newReviewer == nil {
+			return models.ErrNoReplacementFound
+		}
+
+		return s.addReviewerInTx(ctx, tx, pr, newReviewer, reason)
+	})
 	if err != nil {
 		return nil, "", err
 	}
 
+	s.bus.Publish(ctx, events.ReviewerAssigned{
+		PullRequestID: pr.PullRequestID,
+		ReviewerID:    newReviewer.UserID,
+		Reason:        reason,
+	})
+
 	updatedPR, err := s.prRepository.FindByIDWithRelations(prID)
 	if err != nil {
 		return nil, "", err
 	}
 
-	return updatedPR, newReviewerID, nil
+	updatedPR, err = s.withBusinessAge(updatedPR)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return updatedPR, newReviewer.UserID, nil
+}
+
+// SubmitReview records the verdict of a reviewer assigned to an open PR. The reviewer stays assigned and may
+// submit again; review turnaround counts the first verdict of each assignment.
+func (s *PullRequestService) SubmitReview(ctx context.Context, req *models.RequestSubmitReview) (*models.ReviewVerdict, error) {
+	if req.PullRequestID == "" || req.ReviewerID == "" {
+		return nil, fmt.Errorf("%w: pullRequestId and reviewerId are required", models.ErrInvalidReviewVerdict)
+	}
+	if req.Verdict != models.ReviewVerdictApproved && req.Verdict != models.ReviewVerdictChangesRequested {
+		return nil, fmt.Errorf("%w: verdict must be %s or %s", models.ErrInvalidReviewVerdict,
+			models.ReviewVerdictApproved, models.ReviewVerdictChangesRequested)
+	}
+
+	var verdict *models.ReviewVerdict
+	err := s.prRepository.Transaction(func(tx *gorm.DB) error {
+		pr, err := s.prRepository.FindByIDWithReviewersInTx(tx, req.PullRequestID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.ErrPullRequestNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if pr.Status == "MERGED" {
+			return models.ErrPullRequestAlreadyMerged
+		}
+
+		if pr.Status == "CLOSED" {
+			return models.ErrPullRequestClosed
+		}
+
+		i := slices.IndexFunc(pr.AssignedReviewers, func(reviewer models.PullRequestReviewer) bool {
+			return reviewer.UserID == req.ReviewerID
+		})
+		if i < 0 {
+			return models.ErrReviewerNotAssigned
+		}
+
+		assignment := pr.AssignedReviewers[i]
+		verdict = &models.ReviewVerdict{
+			PullRequestID: pr.PullRequestID,
+			UserID:        assignment.UserID,
+			TeamName:      assignment.TeamName,
+			Verdict:       req.Verdict,
+			AssignedAt:    assignment.AssignedAt,
+			SubmittedAt:   time.Now(),
+		}
+		if err := s.prRepository.CreateVerdictInTx(tx, verdict); err != nil {
+			return err
+		}
+
+		event := newPullRequestEvent(ctx, pr.PullRequestID, models.PullRequestEventReviewSubmitted)
+		event.NewReviewerID = verdict.UserID
+		event.Reason = verdict.Verdict
+		if err := s.prRepository.CreateEventsInTx(tx, []models.PullRequestEvent{event}); err != nil {
+			return err
+		}
+
+		return s.audit.RecordInTx(ctx, tx, models.AuditPullRequestReview, models.AuditEntityPullRequest,
+			pr.PullRequestID, nil, verdict)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return verdict, nil
+}
+
+// History returns the timeline of a PR, oldest event first.
+func (s *PullRequestService) History(prID string) (*models.ResponsePullRequestHistory, error) {
+	if prID == "" {
+		return nil, errors.New("pull_request_id cannot be empty")
+	}
+
+	pr, err := s.prRepository.FindByID(prID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, models.ErrPullRequestNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	timeline, err := s.prRepository.GetEvents(prID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ResponsePullRequestHistory{
+		PullRequestID: pr.PullRequestID,
+		Status:        pr.Status,
+		Events:        timeline,
+	}, nil
+}
+
+func (s *PullRequestService) changeStatus(
+	ctx context.Context,
+	operation, eventType, prID, from, to string,
+) (*models.PullRequest, error) {
+	if prID == "" {
+		return nil, errors.New("pull_request_id cannot be empty")
+	}
+
+	pr, err := s.prRepository.FindByIDWithReviewers(prID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, models.ErrPullRequestNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if pr.Status == "MERGED" {
+		return nil, models.ErrPullRequestAlreadyMerged
+	}
+
+	if pr.Status == from {
+		if err := s.prRepository.Transaction(func(tx *gorm.DB) error {
+			return s.changeStatusInTx(ctx, tx, operation, eventType, pr, to)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.withBusinessAge(pr)
+}
+
+// CloseInTx closes an open PR loaded with its reviewers as part of a larger change.
+func (s *PullRequestService) CloseInTx(ctx context.Context, tx *gorm.DB, pr *models.PullRequest) error {
+	return s.changeStatusInTx(ctx, tx, models.AuditPullRequestClose, models.PullRequestEventClosed, pr, "CLOSED")
+}
+
+func (s *PullRequestService) changeStatusInTx(
+	ctx context.Context,
+	tx *gorm.DB,
+	operation, eventType string,
+	pr *models.PullRequest,
+	to string,
+) error {
+	before := pr.ToSnapshot()
+	pr.Status = to
+
+	if err := s.prRepository.UpdateInTx(tx, pr); err != nil {
+		return err
+	}
+
+	if err := s.prRepository.CreateEventsInTx(tx, []models.PullRequestEvent{
+		newPullRequestEvent(ctx, pr.PullRequestID, eventType),
+	}); err != nil {
+		return err
+	}
+
+	return s.audit.RecordInTx(ctx, tx, operation, models.AuditEntityPullRequest, pr.PullRequestID, before, pr.ToSnapshot())
+}
+
+func (s *PullRequestService) withBusinessAge(pr *models.PullRequest) (*models.PullRequest, error) {
+	teamName := pr.OwnerTeam()
+	if teamName == "" {
+		author, err := s.userRepository.FindByID(pr.AuthorID)
+		if err != nil {
+			return nil, err
+		}
+		teamName = author.TeamName
+	}
+
+	teamCalendar, err := s.calendars.ForTeam(teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	pr.BusinessAge = s.calendars.PullRequestAge(teamCalendar, pr, time.Now())
+	return pr, nil
 }
 
 func (s *PullRequestService) validatePullRequestInput(pr *models.PullRequest) error {
@@ -164,6 +473,25 @@ func (s *PullRequestService) checkPRNotExists(prID string) error {
 	return nil
 }
 
+func (s *PullRequestService) validateRepositoryTeam(teamName string) error {
+	if teamName == "" {
+		return nil
+	}
+
+	team, err := s.teamRepository.FindByName(teamName)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.ErrTeamNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if team.ArchivedAt != nil {
+		return models.ErrTeamArchived
+	}
+	return nil
+}
+
 func (s *PullRequestService) validateAuthor(authorID string) (*models.User, error) {
 	author, err := s.userRepository.FindActiveByID(authorID)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -173,9 +501,47 @@ func (s *PullRequestService) validateAuthor(authorID string) (*models.User, erro
 	return author, err
 }
 
-func (s *PullRequestService) createPRInTransaction(pr *models.PullRequest) error {
+func (s *PullRequestService) createPRInTransaction(ctx context.Context, pr *models.PullRequest) error {
 	return s.prRepository.Transaction(func(tx *gorm.DB) error {
-		return s.prRepository.Create(pr)
+		candidates, err := s.reviewerCandidatesInTx(tx, pr, []string{pr.AuthorID})
+		if err != nil {
+			return err
+		}
+
+		pr.AssignedReviewers = s.selectRandomReviewers(candidates, defaultReviewersCount, pr.PullRequestID)
+
+		if err := s.prRepository.CreateInTx(tx, pr); err != nil {
+			return err
+		}
+
+		if err := s.audit.RecordInTx(ctx, tx, models.AuditPullRequestCreate, models.AuditEntityPullRequest,
+			pr.PullRequestID, nil, pr.ToSnapshot()); err != nil {
+			return err
+		}
+
+		timeline := []models.PullRequestEvent{newPullRequestEvent(ctx, pr.PullRequestID, models.PullRequestEventCreated)}
+		for _, reviewer := range pr.AssignedReviewers {
+			event := newPullRequestEvent(ctx, pr.PullRequestID, models.PullRequestEventReviewerAssigned)
+			event.NewReviewerID = reviewer.UserID
+			event.Reason = models.ReviewerChangeInitial
+			timeline = append(timeline, event)
+		}
+
+		if err := s.prRepository.CreateEventsInTx(tx, timeline); err != nil {
+			return err
+		}
+
+		if err := s.bus.PublishInTx(ctx, tx, prCreatedEvents(pr)...); err != nil {
+			return err
+		}
+
+		for _, reviewer := range pr.AssignedReviewers {
+			if err := s.notifications.EnqueueInTx(tx, reviewerAssignedEvent(pr, reviewer.UserID), reviewer.UserID); err != nil {
+				return err
+			}
+		}
+
+		return nil
 	})
 }
 
@@ -202,43 +568,473 @@ func (s *PullRequestService) validateReviewerAssigned(pr *models.PullRequest, ol
 }
 
 func (s *PullRequestService) performReassignment(
+	ctx context.Context,
 	pr *models.PullRequest,
-	oldReviewer *models.User,
-	oldUserID string,
+	oldUserID, reason string,
 ) (string, error) {
-	excludeUserIDs := s.extractReviewerIDs(pr.AssignedReviewers)
+	var newReviewer *models.ReviewerCandidate
+	err := s.prRepository.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if newReviewer, err = s.pickReviewerInTx(tx, pr); err != nil {
+			return err
+		}
 
-	availableReviewers, err := s.userRepository.GetAvailableReviewers(
-		oldReviewer.TeamName,
-		excludeUserIDs,
-		pr.AuthorID,
-	)
+		if newReviewer == nil {
+			return models.ErrNoReplacementFound
+		}
 
+		return s.replaceReviewerInTx(ctx, tx, pr, oldUserID, newReviewer, reason)
+	})
 	if err != nil {
 		return "", err
 	}
 
-	if len(availableReviewers) == 0 {
-		return "", models.ErrNoReplacementFound
+	s.bus.Publish(ctx, events.ReviewerReplaced{
+		PullRequestID: pr.PullRequestID,
+		OldReviewerID: oldUserID,
+		NewReviewerID: newReviewer.UserID,
+		Reason:        reason,
+	})
+
+	return newReviewer.UserID, nil
+}
+
+// ReassignReviewsInTx moves open reviews of the given users to other members of the PR's reviewer pool when the
+// user can no longer review them: they are inactive or left every team of the pool. Without a candidate the review is
+// withdrawn. Callers change the users in the same transaction first and publish ReviewerReplaced events for the
+// result once it is committed.
+func (s *PullRequestService) ReassignReviewsInTx(
+	ctx context.Context,
+	tx *gorm.DB,
+	userIDs []string,
+) ([]models.ReviewReassignment, error) {
+	pullRequests, err := s.prRepository.GetOpenPRsReviewedByInTx(tx, userIDs)
+	if err != nil {
+		return nil, err
 	}
 
-	newReviewer := availableReviewers[s.randomizer.Intn(len(availableReviewers))]
+	affected := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		affected[userID] = true
+	}
 
-	err = s.prRepository.Transaction(func(tx *gorm.DB) error {
-		if err := s.prRepository.DeleteReviewer(pr.PullRequestID, oldUserID); err != nil {
-			return err
+	return s.reassignUnavailableInTx(ctx, tx, pullRequests, func(reviewerID string) bool {
+		return affected[reviewerID]
+	})
+}
+
+// ReassignAuthoredReviewsInTx replaces reviewers of the author's open PRs that are not active members of the
+// PR's reviewer pool, e.g. after the author moved. The same commit rules as ReassignReviewsInTx apply.
+func (s *PullRequestService) ReassignAuthoredReviewsInTx(
+	ctx context.Context,
+	tx *gorm.DB,
+	authorID string,
+) ([]models.ReviewReassignment, error) {
+	pullRequests, err := s.prRepository.GetOpenPRsAuthoredByInTx(tx, authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reassignUnavailableInTx(ctx, tx, pullRequests, func(string) bool {
+		return true
+	})
+}
+
+func (s *PullRequestService) reassignUnavailableInTx(
+	ctx context.Context,
+	tx *gorm.DB,
+	pullRequests []models.PullRequest,
+	affected func(reviewerID string) bool,
+) ([]models.ReviewReassignment, error) {
+	var reassignments []models.ReviewReassignment
+	for i := range pullRequests {
+		pr := &pullRequests[i]
+
+		// Membership of the eligible teams only matters for active reviewers; it is loaded once per PR on demand.
+		var members map[string]bool
+		for _, reviewer := range pr.AssignedReviewers {
+			if !affected(reviewer.UserID) {
+				continue
+			}
+
+			if reviewer.User.IsActive {
+				if members == nil {
+					var err error
+					if members, err = s.eligibleMembersInTx(tx, pr); err != nil {
+						return nil, err
+					}
+				}
+
+				if members[reviewer.UserID] {
+					continue
+				}
+			}
+
+			newReviewerID, err := s.reassignInTx(ctx, tx, pr, reviewer.UserID)
+			if err != nil {
+				return nil, err
+			}
+
+			reassignments = append(reassignments, models.ReviewReassignment{
+				PullRequestID: pr.PullRequestID,
+				OldReviewerID: reviewer.UserID,
+				NewReviewerID: newReviewerID,
+			})
+		}
+	}
+
+	return reassignments, nil
+}
+
+// reviewerReplacedEvents describes committed ReassignReviewsInTx results for the event bus.
+func reviewerReplacedEvents(reassignments []models.ReviewReassignment) []events.Event {
+	published := make([]events.Event, len(reassignments))
+	for i, reassignment := range reassignments {
+		published[i] = events.ReviewerReplaced{
+			PullRequestID: reassignment.PullRequestID,
+			OldReviewerID: reassignment.OldReviewerID,
+			NewReviewerID: reassignment.NewReviewerID,
+			Reason:        models.ReviewerChangeReviewerUnavailable,
 		}
+	}
+
+	return published
+}
+
+func (s *PullRequestService) reassignInTx(
+	ctx context.Context,
+	tx *gorm.DB,
+	pr *models.PullRequest,
+	oldUserID string,
+) (string, error) {
+	newReviewer, err := s.pickReviewerInTx(tx, pr)
+	if err != nil {
+		return "", err
+	}
+
+	newReviewerID := ""
+	if newReviewer != nil {
+		newReviewerID = newReviewer.UserID
+	}
+
+	return newReviewerID, s.replaceReviewerInTx(ctx, tx, pr, oldUserID, newReviewer, models.ReviewerChangeReviewerUnavailable)
+}
+
+// reviewerTeamsInTx is the pool reviewers of the PR are drawn from: its repository team or, without one,
+// every team of the author.
+func (s *PullRequestService) reviewerTeamsInTx(tx *gorm.DB, pr *models.PullRequest) ([]string, error) {
+	if pr.TeamName != "" {
+		return []string{pr.TeamName}, nil
+	}
+
+	return s.teamRepository.FindTeamNamesByUserInTx(tx, pr.AuthorID)
+}
+
+// reviewerCandidatesInTx returns eligible reviewers from the PR's pool. When the pool has none, the search
+// escalates level by level through the team hierarchy and stops at the first level with candidates.
+func (s *PullRequestService) reviewerCandidatesInTx(
+	tx *gorm.DB,
+	pr *models.PullRequest,
+	excludeUserIDs []string,
+) ([]models.ReviewerCandidate, error) {
+	teamNames, err := s.reviewerTeamsInTx(tx, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.userRepository.GetReviewerCandidatesInTx(tx, teamNames, excludeUserIDs)
+	if err != nil || len(candidates) > 0 {
+		return candidates, err
+	}
+
+	levels, err := s.escalationLevelsInTx(tx, teamNames)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, level := range levels {
+		candidates, err := s.userRepository.GetReviewerCandidatesInTx(tx, level, excludeUserIDs)
+		if err != nil || len(candidates) > 0 {
+			return candidates, err
+		}
+	}
+
+	return nil, nil
+}
+
+// escalationLevelsInTx lists the teams to fall back on, nearest first: siblings of the pool teams, their
+// parents, the parents' siblings and so on up to the top of the hierarchy.
+func (s *PullRequestService) escalationLevelsInTx(tx *gorm.DB, teamNames []string) ([][]string, error) {
+	visited := make(map[string]bool, len(teamNames))
+	for _, teamName := range teamNames {
+		visited[teamName] = true
+	}
+
+	unvisited := func(names []string) []string {
+		var fresh []string
+		for _, name := range names {
+			if !visited[name] {
+				visited[name] = true
+				fresh = append(fresh, name)
+			}
+		}
+		return fresh
+	}
+
+	var levels [][]string
+	current := teamNames
+	for len(current) > 0 {
+		parents, err := s.teamRepository.FindParentNamesInTx(tx, current)
+		if err != nil {
+			return nil, err
+		}
+
+		siblings, err := s.teamRepository.FindChildNamesInTx(tx, parents)
+		if err != nil {
+			return nil, err
+		}
+
+		if fresh := unvisited(siblings); len(fresh) > 0 {
+			levels = append(levels, fresh)
+		}
+
+		current = unvisited(parents)
+		if len(current) > 0 {
+			levels = append(levels, current)
+		}
+	}
+
+	return levels, nil
+}
+
+// eligibleTeamsInTx are the teams whose active members may keep a review of the PR: its pool and every
+// escalation level.
+func (s *PullRequestService) eligibleTeamsInTx(tx *gorm.DB, pr *models.PullRequest) ([]string, error) {
+	teamNames, err := s.reviewerTeamsInTx(tx, pr)
+	if err != nil {
+		return nil, err
+	}
 
+	levels, err := s.escalationLevelsInTx(tx, teamNames)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, level := range levels {
+		teamNames = append(teamNames, level...)
+	}
+	return teamNames, nil
+}
+
+func (s *PullRequestService) eligibleMembersInTx(tx *gorm.DB, pr *models.PullRequest) (map[string]bool, error) {
+	teamNames, err := s.eligibleTeamsInTx(tx, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	memberIDs, err := s.teamRepository.FindMemberIDsInTx(tx, teamNames)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make(map[string]bool, len(memberIDs))
+	for _, userID := range memberIDs {
+		members[userID] = true
+	}
+	return members, nil
+}
+
+// pickReviewerInTx returns a random eligible reviewer not yet assigned to the PR, or nil if there is none.
+func (s *PullRequestService) pickReviewerInTx(tx *gorm.DB, pr *models.PullRequest) (*models.ReviewerCandidate, error) {
+	candidates, err := s.reviewerCandidatesInTx(tx, pr, s.excludedReviewerIDs(pr))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.randomCandidate(candidates), nil
+}
+
+func (s *PullRequestService) randomCandidate(candidates []models.ReviewerCandidate) *models.ReviewerCandidate {
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	return &candidates[s.randomizer.Intn(len(candidates))]
+}
+
+func (s *PullRequestService) excludedReviewerIDs(pr *models.PullRequest) []string {
+	return append(s.extractReviewerIDs(pr.AssignedReviewers), pr.AuthorID)
+}
+
+// RestoreReviewInTx gives a review removed by a team deactivation back to its reviewer if nobody changed it
+// since: the replacement chosen at the time still holds it, or, for a withdrawn review, the PR still has a free
+// reviewer slot. It reports whether the review was restored; pr must be open and is updated in place.
+func (s *PullRequestService) RestoreReviewInTx(
+	ctx context.Context,
+	tx *gorm.DB,
+	pr *models.PullRequest,
+	review *models.TeamDeactivationReview,
+) (bool, error) {
+	assigned := s.extractReviewerIDs(pr.AssignedReviewers)
+	if slices.Contains(assigned, review.UserID) {
+		return false, nil
+	}
+
+	reviewer := &models.ReviewerCandidate{
+		User:    models.User{UserID: review.UserID},
+		ViaTeam: review.TeamName,
+	}
+
+	if review.ReplacedBy != "" {
+		if !slices.Contains(assigned, review.ReplacedBy) {
+			return false, nil
+		}
+
+		err := s.replaceReviewerInTx(ctx, tx, pr, review.ReplacedBy, reviewer, models.ReviewerChangeTeamReactivated)
+		return err == nil, err
+	}
+
+	if len(assigned) >= defaultReviewersCount {
+		return false, nil
+	}
+
+	err := s.addReviewerInTx(ctx, tx, pr, reviewer, models.ReviewerChangeTeamReactivated)
+	return err == nil, err
+}
+
+// addReviewerInTx assigns one more reviewer to the PR and appends it to pr.AssignedReviewers.
+func (s *PullRequestService) addReviewerInTx(
+	ctx context.Context,
+	tx *gorm.DB,
+	pr *models.PullRequest,
+	reviewer *models.ReviewerCandidate,
+	reason string,
+) error {
+	newPRReviewer := models.PullRequestReviewer{
+		PullRequestID: pr.PullRequestID,
+		UserID:        reviewer.UserID,
+		TeamName:      reviewer.ViaTeam,
+		AssignedAt:    time.Now(),
+	}
+
+	if err := s.prRepository.CreateReviewerInTx(tx, &newPRReviewer); err != nil {
+		return err
+	}
+
+	before := pr.ToSnapshot()
+	pr.AssignedReviewers = append(pr.AssignedReviewers, newPRReviewer)
+
+	if err := s.audit.RecordInTx(ctx, tx, models.AuditPullRequestAddReviewer, models.AuditEntityPullRequest,
+		pr.PullRequestID, before, pr.ToSnapshot()); err != nil {
+		return err
+	}
+
+	event := newPullRequestEvent(ctx, pr.PullRequestID, models.PullRequestEventReviewerAssigned)
+	event.NewReviewerID = reviewer.UserID
+	event.Reason = reason
+	if err := s.prRepository.CreateEventsInTx(tx, []models.PullRequestEvent{event}); err != nil {
+		return err
+	}
+
+	if err := s.bus.PublishInTx(ctx, tx, events.ReviewerAssigned{
+		PullRequestID: pr.PullRequestID,
+		ReviewerID:    reviewer.UserID,
+		Reason:        reason,
+	}); err != nil {
+		return err
+	}
+
+	return s.notifications.EnqueueInTx(tx, reviewerAssignedEvent(pr, reviewer.UserID), reviewer.UserID)
+}
+
+// replaceReviewerInTx swaps a reviewer and updates pr.AssignedReviewers to match;
+// a nil replacement only withdraws the old reviewer.
+func (s *PullRequestService) replaceReviewerInTx(
+	ctx context.Context,
+	tx *gorm.DB,
+	pr *models.PullRequest,
+	oldUserID string,
+	replacement *models.ReviewerCandidate,
+	reason string,
+) error {
+	before := pr.ToSnapshot()
+
+	newUserID := ""
+	if replacement != nil {
+		newUserID = replacement.UserID
+	}
+
+	if err := s.prRepository.DeleteReviewerInTx(tx, pr.PullRequestID, oldUserID); err != nil {
+		return err
+	}
+
+	reviewers := make([]models.PullRequestReviewer, 0, len(pr.AssignedReviewers))
+	for _, reviewer := range pr.AssignedReviewers {
+		if reviewer.UserID != oldUserID {
+			reviewers = append(reviewers, reviewer)
+		}
+	}
+
+	if newUserID != "" {
 		newPRReviewer := models.PullRequestReviewer{
 			PullRequestID: pr.PullRequestID,
-			UserID:        newReviewer.UserID,
+			UserID:        newUserID,
+			TeamName:      replacement.ViaTeam,
 			AssignedAt:    time.Now(),
 		}
 
-		return s.prRepository.CreateReviewer(&newPRReviewer)
-	})
+		if err := s.prRepository.CreateReviewerInTx(tx, &newPRReviewer); err != nil {
+			return err
+		}
+		reviewers = append(reviewers, newPRReviewer)
+	}
+
+	pr.AssignedReviewers = reviewers
+
+	if err := s.audit.RecordInTx(ctx, tx, models.AuditPullRequestReassign, models.AuditEntityPullRequest,
+		pr.PullRequestID, before, pr.ToSnapshot()); err != nil {
+		return err
+	}
+
+	eventType := models.PullRequestEventReviewerReassigned
+	if newUserID == "" {
+		eventType = models.PullRequestEventReviewerRemoved
+	}
+
+	event := newPullRequestEvent(ctx, pr.PullRequestID, eventType)
+	event.OldReviewerID = oldUserID
+	event.NewReviewerID = newUserID
+	event.Reason = reason
+	if err := s.prRepository.CreateEventsInTx(tx, []models.PullRequestEvent{event}); err != nil {
+		return err
+	}
+
+	if err := s.bus.PublishInTx(ctx, tx, events.ReviewerReplaced{
+		PullRequestID: pr.PullRequestID,
+		OldReviewerID: oldUserID,
+		NewReviewerID: newUserID,
+		Reason:        reason,
+	}); err != nil {
+		return err
+	}
+
+	if newUserID != "" {
+		if err := s.notifications.EnqueueInTx(tx, reviewerAssignedEvent(pr, newUserID), newUserID); err != nil {
+			return err
+		}
+	}
 
-	return newReviewer.UserID, err
+	return s.notifications.EnqueueInTx(tx, reviewerUnassignedEvent(pr, oldUserID, newUserID), oldUserID)
+}
+
+// newPullRequestEvent stamps a timeline event with the acting identity from ctx.
+func newPullRequestEvent(ctx context.Context, prID, eventType string) models.PullRequestEvent {
+	return models.PullRequestEvent{
+		PullRequestID: prID,
+		EventType:     eventType,
+		Actor:         audit.Actor(ctx),
+		OccurredAt:    time.Now(),
+	}
 }
 
 func (s *PullRequestService) extractReviewerIDs(reviewers []models.PullRequestReviewer) []string {
@@ -251,25 +1047,26 @@ func (s *PullRequestService) extractReviewerIDs(reviewers []models.PullRequestRe
 }
 
 func (s *PullRequestService) selectRandomReviewers(
-	users []models.User,
+	candidates []models.ReviewerCandidate,
 	maxCount int,
 	pullRequestID string,
 ) []models.PullRequestReviewer {
-	if len(users) == 0 {
+	if len(candidates) == 0 {
 		return []models.PullRequestReviewer{}
 	}
 
-	if len(users) < maxCount {
-		maxCount = len(users)
+	if len(candidates) < maxCount {
+		maxCount = len(candidates)
 	}
 
-	shuffled := s.shuffleUsers(users)
+	shuffled := s.shuffleCandidates(candidates)
 	reviewers := make([]models.PullRequestReviewer, maxCount)
 
 	for i := 0; i < maxCount; i++ {
 		reviewers[i] = models.PullRequestReviewer{
 			PullRequestID: pullRequestID,
 			UserID:        shuffled[i].UserID,
+			TeamName:      shuffled[i].ViaTeam,
 			AssignedAt:    time.Now(),
 		}
 	}
@@ -277,9 +1074,9 @@ func (s *PullRequestService) selectRandomReviewers(
 	return reviewers
 }
 
-func (s *PullRequestService) shuffleUsers(users []models.User) []models.User {
-	shuffled := make([]models.User, len(users))
-	copy(shuffled, users)
+func (s *PullRequestService) shuffleCandidates(candidates []models.ReviewerCandidate) []models.ReviewerCandidate {
+	shuffled := make([]models.ReviewerCandidate, len(candidates))
+	copy(shuffled, candidates)
 
 	s.randomizer.Shuffle(len(shuffled), func(i, j int) {
 		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
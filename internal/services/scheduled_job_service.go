@@ -0,0 +1,274 @@
+package services
+
+import (
+	"CodeRewievService/internal/audit"
+	"CodeRewievService/internal/models"
+	"CodeRewievService/internal/repository"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	scheduledJobBatchSize     = 20
+	scheduledJobLease         = 10 * time.Minute
+	defaultScheduledJobsLimit = 100
+	maxScheduledJobsLimit     = 1000
+
+	scheduledJobInterrupted = "interrupted: the worker stopped before the job finished"
+)
+
+// ScheduledJobService stores changes scheduled for a future time and runs them once due, through the same
+// services as the corresponding endpoints and on behalf of the actor who scheduled them.
+type ScheduledJobService struct {
+	jobRepository  *repository.ScheduledJobRepository
+	userRepository *repository.UserRepository
+	teamRepository *repository.TeamRepository
+	users          *UserService
+	teams          *TeamService
+	audit          *AuditService
+	logger         *slog.Logger
+}
+
+func NewScheduledJobService(
+	jobRepository *repository.ScheduledJobRepository,
+	userRepository *repository.UserRepository,
+	teamRepository *repository.TeamRepository,
+	users *UserService,
+	teams *TeamService,
+	audit *AuditService,
+	logger *slog.Logger,
+) *ScheduledJobService {
+	return &ScheduledJobService{
+		jobRepository:  jobRepository,
+		userRepository: userRepository,
+		teamRepository: teamRepository,
+		users:          users,
+		teams:          teams,
+		audit:          audit,
+		logger:         logger,
+	}
+}
+
+func (s *ScheduledJobService) Schedule(ctx context.Context, req *models.RequestScheduleJob) (*models.ScheduledJob, error) {
+	job, err := s.newJob(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.jobRepository.Transaction(func(tx *gorm.DB) error {
+		if err := s.jobRepository.CreateInTx(tx, job); err != nil {
+			return err
+		}
+
+		return s.audit.RecordInTx(ctx, tx, models.AuditScheduledJobCreate, models.AuditEntityScheduledJob,
+			fmt.Sprint(job.ID), nil, job)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// Cancel stops a job that has not run yet.
+func (s *ScheduledJobService) Cancel(ctx context.Context, jobID int64) (*models.ScheduledJob, error) {
+	var job *models.ScheduledJob
+	err := s.jobRepository.Transaction(func(tx *gorm.DB) error {
+		var err error
+		job, err = s.jobRepository.FindByIDForUpdateInTx(tx, jobID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.ErrScheduledJobNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if job.Status != models.ScheduledJobPending {
+			return fmt.Errorf("%w: job is %s", models.ErrScheduledJobNotPending, job.Status)
+		}
+
+		before := *job
+		cancelledAt := time.Now()
+		job.Status = models.ScheduledJobCancelled
+		job.FinishedAt = &cancelledAt
+		if err := s.jobRepository.FinishInTx(tx, job); err != nil {
+			return err
+		}
+
+		return s.audit.RecordInTx(ctx, tx, models.AuditScheduledJobCancel, models.AuditEntityScheduledJob,
+			fmt.Sprint(job.ID), before, job)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (s *ScheduledJobService) Get(jobID int64) (*models.ScheduledJob, error) {
+	job, err := s.jobRepository.FindByID(jobID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, models.ErrScheduledJobNotFound
+	}
+
+	return job, err
+}
+
+func (s *ScheduledJobService) List(filter models.ScheduledJobFilter) ([]models.ScheduledJob, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultScheduledJobsLimit
+	}
+	if filter.Limit > maxScheduledJobsLimit {
+		filter.Limit = maxScheduledJobsLimit
+	}
+
+	return s.jobRepository.List(filter)
+}
+
+// RunDue executes pending jobs whose time has come and returns how many ran. Jobs are claimed as RUNNING before
+// they execute, so a job is cancelled either before it runs or not at all. Each job commits through its own
+// service and its outcome is recorded afterwards; a job that fails is not retried. Jobs still RUNNING after
+// scheduledJobLease belonged to a worker that died and are failed as interrupted, and claimed jobs that did not
+// start before shutdown are returned to PENDING.
+func (s *ScheduledJobService) RunDue(ctx context.Context, now time.Time) (int, error) {
+	interrupted, err := s.jobRepository.FailStale(now.Add(-scheduledJobLease), now, scheduledJobInterrupted)
+	if err != nil {
+		return 0, err
+	}
+	if interrupted > 0 {
+		s.logger.Warn("Failed interrupted scheduled jobs", "count", interrupted)
+	}
+
+	jobs, err := s.jobRepository.ClaimDue(now, scheduledJobBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	for i := range jobs {
+		if ctx.Err() != nil {
+			if err := s.jobRepository.Release(scheduledJobIDs(jobs[i:])); err != nil {
+				errs = append(errs, err)
+			}
+			return i, errors.Join(append(errs, ctx.Err())...)
+		}
+
+		job := &jobs[i]
+		s.execute(ctx, job)
+
+		if err := s.jobRepository.Finish(job); err != nil {
+			s.logger.Error("Failed to record scheduled job outcome", "jobID", job.ID, "status", job.Status, "error", err)
+			errs = append(errs, fmt.Errorf("job %d: %w", job.ID, err))
+		}
+	}
+
+	return len(jobs), errors.Join(errs...)
+}
+
+func (s *ScheduledJobService) execute(ctx context.Context, job *models.ScheduledJob) {
+	ctx = audit.WithActor(ctx, job.Actor)
+
+	var result interface{}
+	var err error
+	switch job.JobType {
+	case models.ScheduledJobSetIsActive:
+		result, err = s.setIsActive(ctx, job)
+	case models.ScheduledJobTeamDeactivate:
+		result, err = s.teams.MassDeactivateTeamUsers(ctx, job.TeamName, false)
+	default:
+		err = fmt.Errorf("unknown job type %q", job.JobType)
+	}
+
+	if err == nil {
+		job.Result, err = json.Marshal(result)
+	}
+
+	finishedAt := time.Now()
+	job.FinishedAt = &finishedAt
+
+	if err != nil {
+		job.Status = models.ScheduledJobFailed
+		job.Error = truncate(err.Error(), maxNotificationErrorLength)
+		s.logger.Warn("Scheduled job failed", "jobID", job.ID, "type", job.JobType, "error", err)
+		return
+	}
+
+	job.Status = models.ScheduledJobSucceeded
+}
+
+// setIsActive goes through the bulk change so that a scheduled deactivation reassigns open reviews like the
+// endpoint does.
+func (s *ScheduledJobService) setIsActive(ctx context.Context, job *models.ScheduledJob) (*models.ResponseBulkSetIsActive, error) {
+	result, err := s.users.BulkSetIsActive(ctx, &models.RequestBulkSetIsActive{
+		UserIDs:  []string{job.UserID},
+		IsActive: job.IsActive,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Results[0].Status == models.BulkUserNotFound {
+		return nil, models.ErrUserNotFound
+	}
+
+	return result, nil
+}
+
+func (s *ScheduledJobService) newJob(ctx context.Context, req *models.RequestScheduleJob) (*models.ScheduledJob, error) {
+	if req.RunAt.IsZero() || !req.RunAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: runAt must be in the future", models.ErrInvalidScheduledJob)
+	}
+
+	job := &models.ScheduledJob{
+		JobType: req.Type,
+		RunAt:   req.RunAt,
+		Status:  models.ScheduledJobPending,
+		Actor:   audit.Actor(ctx),
+	}
+
+	switch req.Type {
+	case models.ScheduledJobSetIsActive:
+		if req.UserID == "" || req.IsActive == nil {
+			return nil, fmt.Errorf("%w: userId and isActive are required", models.ErrInvalidScheduledJob)
+		}
+
+		if _, err := s.userRepository.FindByID(req.UserID); errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.ErrUserNotFound
+		} else if err != nil {
+			return nil, err
+		}
+
+		job.UserID = req.UserID
+		job.IsActive = *req.IsActive
+	case models.ScheduledJobTeamDeactivate:
+		if req.TeamName == "" {
+			return nil, fmt.Errorf("%w: teamName is required", models.ErrInvalidScheduledJob)
+		}
+
+		if _, err := s.teamRepository.FindByName(req.TeamName); errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.ErrTeamNotFound
+		} else if err != nil {
+			return nil, err
+		}
+
+		job.TeamName = req.TeamName
+	default:
+		return nil, fmt.Errorf("%w: type must be %s or %s", models.ErrInvalidScheduledJob,
+			models.ScheduledJobSetIsActive, models.ScheduledJobTeamDeactivate)
+	}
+
+	return job, nil
+}
+
+func scheduledJobIDs(jobs []models.ScheduledJob) []int64 {
+	ids := make([]int64, len(jobs))
+	for i := range jobs {
+		ids[i] = jobs[i].ID
+	}
+	return ids
+}
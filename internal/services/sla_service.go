@@ -0,0 +1,210 @@
+package services
+
+import (
+	"CodeRewievService/internal/calendar"
+	"CodeRewievService/internal/models"
+	"CodeRewievService/internal/repository"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type SLAService struct {
+	slaRepository  *repository.SLARepository
+	teamRepository *repository.TeamRepository
+	prService      *PullRequestService
+	calendars      *CalendarService
+	notifications  *NotificationService
+	logger         *slog.Logger
+}
+
+func NewSLAService(
+	slaRepository *repository.SLARepository,
+	teamRepository *repository.TeamRepository,
+	prService *PullRequestService,
+	calendars *CalendarService,
+	notifications *NotificationService,
+	logger *slog.Logger,
+) *SLAService {
+	return &SLAService{
+		slaRepository:  slaRepository,
+		teamRepository: teamRepository,
+		prService:      prService,
+		calendars:      calendars,
+		notifications:  notifications,
+		logger:         logger,
+	}
+}
+
+func (s *SLAService) SetTeamSLA(sla *models.ReviewSLA) (*models.ReviewSLA, error) {
+	if err := s.validateSLAInput(sla); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateTeamExists(sla.TeamName); err != nil {
+		return nil, err
+	}
+
+	if err := s.slaRepository.Upsert(sla); err != nil {
+		return nil, err
+	}
+
+	return s.slaRepository.FindByTeam(sla.TeamName)
+}
+
+func (s *SLAService) GetTeamSLA(teamName string) (*models.ReviewSLA, error) {
+	if teamName == "" {
+		return nil, errors.New("team name cannot be empty")
+	}
+
+	sla, err := s.slaRepository.FindByTeam(teamName)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, models.ErrSLANotFound
+	}
+
+	return sla, err
+}
+
+func (s *SLAService) GetOverdueReviews(teamName string) ([]models.OverdueReview, error) {
+	if teamName != "" {
+		if err := s.validateTeamExists(teamName); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.slaRepository.GetCurrentBreaches(teamName)
+}
+
+// EscalateOverdueReviews applies the SLA action to PRs whose first review passed its deadline; changes are
+// audited under the actor carried by ctx. Reviews are not marked as done, so "first review" is the earliest
+// assignment that is still open: each PR is escalated once, and reviewers it assigns get no SLA of their own.
+func (s *SLAService) EscalateOverdueReviews(ctx context.Context, now time.Time) (int, error) {
+	pending, err := s.slaRepository.GetPendingReviewsOlderThan(now)
+	if err != nil {
+		return 0, err
+	}
+
+	escalated := 0
+	teamCalendars := make(map[string]*calendar.Calendar)
+
+	for i := range pending {
+		review := &pending[i]
+
+		teamCalendar, ok := teamCalendars[review.TeamName]
+		if !ok {
+			if teamCalendar, err = s.calendars.ForTeam(review.TeamName); err != nil {
+				return escalated, err
+			}
+			teamCalendars[review.TeamName] = teamCalendar
+		}
+
+		deadline := s.deadlineFor(teamCalendar, review)
+		if !deadline.Before(now) {
+			continue
+		}
+
+		breach := &models.ReviewSLABreach{
+			PullRequestID: review.PullRequestID,
+			UserID:        review.UserID,
+			TeamName:      review.TeamName,
+			AssignedAt:    review.AssignedAt,
+			DeadlineAt:    deadline,
+			DetectedAt:    now,
+			Action:        review.Action,
+		}
+
+		created, err := s.slaRepository.ClaimEscalation(breach)
+		if err != nil {
+			return escalated, err
+		}
+		if !created {
+			continue
+		}
+
+		result, err := s.applyAction(ctx, review, deadline)
+		if err != nil {
+			s.logActionFailed(review, err)
+			if err := s.slaRepository.ReleaseEscalation(breach); err != nil {
+				return escalated, err
+			}
+			continue
+		}
+
+		if err := s.slaRepository.UpdateBreachResult(breach.ID, result); err != nil {
+			return escalated, err
+		}
+
+		escalated++
+	}
+
+	return escalated, nil
+}
+
+// deadlineFor counts the SLA in the team's business time, so nights, weekends and holidays do not burn it.
+func (s *SLAService) deadlineFor(teamCalendar *calendar.Calendar, review *models.PendingReview) time.Time {
+	return teamCalendar.AddBusinessTime(review.AssignedAt, time.Duration(review.FirstReviewMinutes)*time.Minute)
+}
+
+// applyAction runs the SLA action and describes its outcome for the breach record.
+func (s *SLAService) applyAction(ctx context.Context, review *models.PendingReview, deadline time.Time) (string, error) {
+	switch review.Action {
+	case models.SLAActionAddReviewer:
+		_, newReviewerID, err := s.prService.AddReviewer(ctx, review.PullRequestID, models.ReviewerChangeSLAEscalation)
+		if err != nil {
+			return "", err
+		}
+		return "added reviewer " + newReviewerID, nil
+
+	case models.SLAActionReassign:
+		_, newReviewerID, err := s.prService.Reassign(ctx, review.PullRequestID, review.UserID, models.ReviewerChangeSLAEscalation)
+		if err != nil {
+			return "", err
+		}
+		return "reassigned to " + newReviewerID, nil
+
+	default:
+		if err := s.notifications.Enqueue(reviewSLABreachedEvent(review, deadline), review.UserID); err != nil {
+			return "", err
+		}
+		return "notified", nil
+	}
+}
+
+func (s *SLAService) logActionFailed(review *models.PendingReview, err error) {
+	s.logger.Error("Failed to escalate overdue review, will retry on the next check",
+		"error", err,
+		"prID", review.PullRequestID,
+		"reviewerID", review.UserID,
+		"action", review.Action,
+	)
+}
+
+func (s *SLAService) validateSLAInput(sla *models.ReviewSLA) error {
+	if sla == nil {
+		return errors.New("review sla cannot be nil")
+	}
+	if sla.TeamName == "" {
+		return errors.New("team name cannot be empty")
+	}
+	if sla.FirstReviewMinutes <= 0 {
+		return models.ErrInvalidSLA
+	}
+	if sla.Action == "" {
+		sla.Action = models.SLAActionNotify
+	}
+	if !models.IsValidSLAAction(sla.Action) {
+		return models.ErrInvalidSLA
+	}
+	return nil
+}
+
+func (s *SLAService) validateTeamExists(teamName string) error {
+	_, err := s.teamRepository.FindByName(teamName)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.ErrTeamNotFound
+	}
+	return err
+}
@@ -1,44 +1,560 @@
 package services
 
 import (
+	"CodeRewievService/internal/calendar"
+	"CodeRewievService/internal/events"
 	"CodeRewievService/internal/models"
 	"CodeRewievService/internal/repository"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// maxStatisticsBuckets bounds a time series to about a year of days.
+	maxStatisticsBuckets    = 366
+	defaultLeaderboardLimit = 10
+	maxLeaderboardLimit     = 100
 )
 
+// defaultStatisticsPeriods is how many periods a time series covers when from is omitted.
+var defaultStatisticsPeriods = map[string]int{
+	models.StatisticsGroupByDay:   30,
+	models.StatisticsGroupByWeek:  12,
+	models.StatisticsGroupByMonth: 12,
+}
+
 type StatisticsService struct {
-	statsRepository *repository.StatisticsRepository
+	statsRepository    *repository.StatisticsRepository
+	calendars          *CalendarService
+	bus                *events.Bus
+	imbalanceThreshold float64
 }
 
-func NewStatisticsService(statsRepository *repository.StatisticsRepository) *StatisticsService {
+// NewStatisticsService takes the Gini coefficient of open review load above which a team counts as imbalanced.
+func NewStatisticsService(
+	statsRepository *repository.StatisticsRepository,
+	calendars *CalendarService,
+	bus *events.Bus,
+	imbalanceThreshold float64,
+) *StatisticsService {
 	return &StatisticsService{
-		statsRepository: statsRepository,
+		statsRepository:    statsRepository,
+		calendars:          calendars,
+		bus:                bus,
+		imbalanceThreshold: imbalanceThreshold,
 	}
 }
 
-func (s *StatisticsService) GetAssignmentsStats(teamName string) ([]models.AssignmentStats, error) {
-	users, err := s.statsRepository.GetTeamUsers(teamName)
+// GetAssignmentsStats counts reviews assigned on behalf of the team; with IncludeSubTeams the sub-teams' members
+// and reviews roll up into it. With GroupBy every member also gets a gap-free time series over the window.
+func (s *StatisticsService) GetAssignmentsStats(filter models.StatisticsFilter) ([]models.AssignmentStats, error) {
+	if err := normalizeStatisticsFilter(&filter, time.Now()); err != nil {
+		return nil, err
+	}
+
+	teamNames, err := s.teamNames(filter.TeamName, filter.IncludeSubTeams)
 	if err != nil {
 		return nil, err
 	}
 
-	assignmentStats := make([]models.AssignmentStats, 0, len(users))
-	for _, user := range users {
-		assignedReviews, err := s.statsRepository.CountUserAssignedReviews(user.UserID)
-		if err != nil {
-			continue
+	assignmentStats, err := s.statsRepository.GetAssignmentCounts(teamNames, filter.From, filter.To)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.GroupBy != "" {
+		if err := s.attachSeries(assignmentStats, teamNames, filter); err != nil {
+			return nil, err
 		}
+	}
 
-		stats := models.AssignmentStats{
-			UserID:          user.UserID,
-			Username:        user.Username,
-			AssignedReviews: int(assignedReviews),
+	openReviewAges, err := s.getOpenReviewAges(filter.TeamName, teamNames)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range assignmentStats {
+		stats := &assignmentStats[i]
+		if ages := openReviewAges[stats.UserID]; len(ages) > 0 {
+			stats.AvgOpenReviewAgeMinutes = int64(averageDuration(ages) / time.Minute)
 		}
-		assignmentStats = append(assignmentStats, stats)
 	}
 
+	if assignmentStats == nil {
+		assignmentStats = []models.AssignmentStats{}
+	}
 	return assignmentStats, nil
 }
 
+// attachSeries fills Series of every member with one bucket per period of the window, zeros included.
+func (s *StatisticsService) attachSeries(assignmentStats []models.AssignmentStats, teamNames []string, filter models.StatisticsFilter) error {
+	buckets, err := s.statsRepository.GetAssignmentSeries(teamNames, filter.GroupBy, *filter.From, *filter.To)
+	if err != nil {
+		return err
+	}
+
+	byUser := make(map[string]map[time.Time]models.StatisticsBucket)
+	for _, bucket := range buckets {
+		if byUser[bucket.UserID] == nil {
+			byUser[bucket.UserID] = make(map[time.Time]models.StatisticsBucket)
+		}
+		byUser[bucket.UserID][bucket.PeriodStart.UTC()] = bucket
+	}
+
+	periods := statisticsPeriods(filter.GroupBy, *filter.From, *filter.To)
+	for i := range assignmentStats {
+		stats := &assignmentStats[i]
+		stats.Series = make([]models.StatisticsBucket, 0, len(periods))
+		for _, period := range periods {
+			bucket, ok := byUser[stats.UserID][period]
+			if !ok {
+				bucket = models.StatisticsBucket{UserID: stats.UserID}
+			}
+			bucket.PeriodStart = period
+			stats.Series = append(stats.Series, bucket)
+		}
+	}
+
+	return nil
+}
+
+// normalizeStatisticsFilter validates the window and, for a time series, defaults it to the last periods
+// before now.
+func normalizeStatisticsFilter(filter *models.StatisticsFilter, now time.Time) error {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return fmt.Errorf("%w: from must be before to", models.ErrInvalidStatisticsRequest)
+	}
+
+	if filter.GroupBy == "" {
+		return nil
+	}
+
+	periods, ok := defaultStatisticsPeriods[filter.GroupBy]
+	if !ok {
+		return fmt.Errorf("%w: groupBy must be one of day, week, month", models.ErrInvalidStatisticsRequest)
+	}
+
+	if filter.To == nil {
+		filter.To = &now
+	}
+	if filter.From == nil {
+		from := truncatePeriod(filter.GroupBy, *filter.To)
+		for i := 0; i < periods; i++ {
+			from = addPeriod(filter.GroupBy, from, -1)
+		}
+		filter.From = &from
+	}
+
+	if len(statisticsPeriods(filter.GroupBy, *filter.From, *filter.To)) > maxStatisticsBuckets {
+		return fmt.Errorf("%w: window is too long for groupBy=%s", models.ErrInvalidStatisticsRequest, filter.GroupBy)
+	}
+
+	return nil
+}
+
+// statisticsPeriods lists the starts of the periods overlapping [from, to), as date_trunc computes them in UTC.
+func statisticsPeriods(groupBy string, from, to time.Time) []time.Time {
+	var periods []time.Time
+	for period := truncatePeriod(groupBy, from); period.Before(to); period = addPeriod(groupBy, period, 1) {
+		periods = append(periods, period)
+		if len(periods) > maxStatisticsBuckets {
+			break
+		}
+	}
+	return periods
+}
+
+func truncatePeriod(groupBy string, t time.Time) time.Time {
+	t = t.UTC()
+	switch groupBy {
+	case models.StatisticsGroupByWeek:
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case models.StatisticsGroupByMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+}
+
+func addPeriod(groupBy string, t time.Time, n int) time.Time {
+	switch groupBy {
+	case models.StatisticsGroupByWeek:
+		return t.AddDate(0, 0, 7*n)
+	case models.StatisticsGroupByMonth:
+		return t.AddDate(0, n, 0)
+	default:
+		return t.AddDate(0, 0, n)
+	}
+}
+
 func (s *StatisticsService) TeamExists(teamName string) (bool, error) {
 	return s.statsRepository.TeamExists(teamName)
 }
+
+// GetFairness shows how evenly open and historical reviews of the team are spread over its active members.
+func (s *StatisticsService) GetFairness(teamName string, includeSubTeams bool) (*models.TeamFairness, error) {
+	teamNames, err := s.teamNames(teamName, includeSubTeams)
+	if err != nil {
+		return nil, err
+	}
+
+	assignmentStats, err := s.statsRepository.GetAssignmentCounts(teamNames, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return teamFairness(teamName, assignmentStats, s.imbalanceThreshold), nil
+}
+
+// CheckLoadImbalance raises an imbalance alert for every team whose open review load crossed the threshold
+// since the last check and clears alerts of teams that evened out. Returns the number of alerts raised.
+func (s *StatisticsService) CheckLoadImbalance(ctx context.Context, now time.Time) (int, error) {
+	teamNames, err := s.statsRepository.GetActiveTeamNames()
+	if err != nil {
+		return 0, err
+	}
+
+	raised := 0
+	for _, teamName := range teamNames {
+		assignmentStats, err := s.statsRepository.GetAssignmentCounts([]string{teamName}, nil, nil)
+		if err != nil {
+			return raised, err
+		}
+
+		fairness := teamFairness(teamName, assignmentStats, s.imbalanceThreshold)
+		if !fairness.Imbalanced {
+			if err := s.statsRepository.DeleteImbalanceAlert(teamName); err != nil {
+				return raised, err
+			}
+			continue
+		}
+
+		imbalanced := events.LoadImbalanced{
+			TeamName:  teamName,
+			Gini:      fairness.Open.Gini,
+			Threshold: fairness.Threshold,
+			Open:      fairness.Open,
+			Members:   fairness.Members,
+		}
+
+		var created bool
+		err = s.statsRepository.Transaction(func(tx *gorm.DB) error {
+			inserted, err := s.statsRepository.CreateImbalanceAlertInTx(tx, &models.TeamLoadImbalanceAlert{
+				TeamName:        teamName,
+				GiniCoefficient: fairness.Open.Gini,
+				Threshold:       fairness.Threshold,
+				RaisedAt:        now,
+			})
+			if err != nil || !inserted {
+				return err
+			}
+			created = true
+
+			return s.bus.PublishInTx(ctx, tx, imbalanced)
+		})
+		if err != nil {
+			return raised, err
+		}
+
+		if created {
+			raised++
+			s.bus.Publish(ctx, imbalanced)
+		}
+	}
+
+	return raised, nil
+}
+
+// GetTurnaround measures the cycle time of the team's PRs merged within the window and the time reviewers take to
+// submit their first verdict, for the team and per reviewer, on the calendar of the team.
+func (s *StatisticsService) GetTurnaround(filter models.StatisticsFilter) (*models.TeamTurnaround, error) {
+	filter.GroupBy = ""
+	if err := normalizeStatisticsFilter(&filter, time.Now()); err != nil {
+		return nil, err
+	}
+
+	teamNames, err := s.teamNames(filter.TeamName, filter.IncludeSubTeams)
+	if err != nil {
+		return nil, err
+	}
+
+	pullRequests, err := s.statsRepository.GetCycleTimeSamples(teamNames, filter.From, filter.To)
+	if err != nil {
+		return nil, err
+	}
+
+	teamCalendar, err := s.calendars.ForTeam(filter.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	turnaround := &models.TeamTurnaround{
+		TeamName: filter.TeamName,
+		From:     filter.From,
+		To:       filter.To,
+	}
+
+	cycleTimes := make([]time.Duration, 0, len(pullRequests))
+	for _, pr := range pullRequests {
+		cycleTimes = append(cycleTimes, teamCalendar.BusinessElapsed(pr.CreatedAt, pr.MergedAt))
+	}
+	turnaround.CycleTime = durationPercentiles(cycleTimes)
+
+	reviews, err := s.statsRepository.GetReviewTurnaroundSamples(teamNames, filter.From, filter.To)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewed, err := s.statsRepository.GetReviewerCycleTimeSamples(teamNames, filter.From, filter.To)
+	if err != nil {
+		return nil, err
+	}
+
+	turnaround.ReviewTurnaround, turnaround.Reviewers = reviewerTurnarounds(teamCalendar, reviews, reviewed)
+
+	return turnaround, nil
+}
+
+// reviewerTurnarounds returns the review turnaround of all verdicts and the turnaround of every reviewer who has
+// a verdict or a merged PR, ordered by user ID.
+func reviewerTurnarounds(
+	teamCalendar *calendar.Calendar,
+	reviews []models.ReviewTurnaroundSample,
+	reviewed []models.ReviewerCycleTimeSample,
+) (models.DurationPercentiles, []models.ReviewerTurnaround) {
+	type reviewerDurations struct {
+		username                string
+		reviewTimes, cycleTimes []time.Duration
+	}
+
+	byReviewer := make(map[string]*reviewerDurations)
+	reviewer := func(userID, username string) *reviewerDurations {
+		if byReviewer[userID] == nil {
+			byReviewer[userID] = &reviewerDurations{username: username}
+		}
+		return byReviewer[userID]
+	}
+
+	reviewTimes := make([]time.Duration, 0, len(reviews))
+	for _, review := range reviews {
+		elapsed := teamCalendar.BusinessElapsed(review.AssignedAt, review.SubmittedAt)
+		reviewTimes = append(reviewTimes, elapsed)
+
+		durations := reviewer(review.UserID, review.Username)
+		durations.reviewTimes = append(durations.reviewTimes, elapsed)
+	}
+
+	for _, pr := range reviewed {
+		durations := reviewer(pr.UserID, pr.Username)
+		durations.cycleTimes = append(durations.cycleTimes, teamCalendar.BusinessElapsed(pr.CreatedAt, pr.MergedAt))
+	}
+
+	reviewers := make([]models.ReviewerTurnaround, 0, len(byReviewer))
+	for userID, durations := range byReviewer {
+		reviewers = append(reviewers, models.ReviewerTurnaround{
+			UserID:           userID,
+			Username:         durations.username,
+			ReviewTurnaround: durationPercentiles(durations.reviewTimes),
+			CycleTime:        durationPercentiles(durations.cycleTimes),
+		})
+	}
+	sort.Slice(reviewers, func(i, j int) bool { return reviewers[i].UserID < reviewers[j].UserID })
+
+	return durationPercentiles(reviewTimes), reviewers
+}
+
+// GetOverview totals PRs of every team and ranks reviewers across the organisation within the window.
+func (s *StatisticsService) GetOverview(filter models.OverviewFilter) (*models.OrgOverview, error) {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, fmt.Errorf("%w: from must be before to", models.ErrInvalidStatisticsRequest)
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultLeaderboardLimit
+	}
+	if filter.Limit > maxLeaderboardLimit {
+		filter.Limit = maxLeaderboardLimit
+	}
+
+	teams, err := s.statsRepository.GetTeamOverviews(filter.From, filter.To, defaultReviewersCount)
+	if err != nil {
+		return nil, err
+	}
+
+	leaderboard, err := s.statsRepository.GetLeaderboard(filter.From, filter.To, filter.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range leaderboard {
+		leaderboard[i].Rank = i + 1
+	}
+
+	if teams == nil {
+		teams = []models.TeamOverview{}
+	}
+	if leaderboard == nil {
+		leaderboard = []models.LeaderboardEntry{}
+	}
+
+	return &models.OrgOverview{
+		From:        filter.From,
+		To:          filter.To,
+		Teams:       teams,
+		Leaderboard: leaderboard,
+	}, nil
+}
+
+func (s *StatisticsService) teamNames(teamName string, includeSubTeams bool) ([]string, error) {
+	teamNames := []string{teamName}
+	if includeSubTeams {
+		subTeams, err := s.statsRepository.GetSubTeamNames(teamName)
+		if err != nil {
+			return nil, err
+		}
+		teamNames = append(teamNames, subTeams...)
+	}
+	return teamNames, nil
+}
+
+// teamFairness measures the load of active members only, since inactive ones cannot be assigned. A team
+// is imbalanced only with at least two members and as many open reviews as members: a handful of reviews
+// is always uneven.
+func teamFairness(teamName string, assignmentStats []models.AssignmentStats, threshold float64) *models.TeamFairness {
+	members := make([]models.MemberLoad, 0, len(assignmentStats))
+	openLoads := make([]int, 0, len(assignmentStats))
+	historicalLoads := make([]int, 0, len(assignmentStats))
+	for _, stats := range assignmentStats {
+		if !stats.IsActive {
+			continue
+		}
+		members = append(members, models.MemberLoad{
+			UserID:          stats.UserID,
+			Username:        stats.Username,
+			OpenReviews:     stats.OpenReviews,
+			AssignedReviews: stats.AssignedReviews,
+		})
+		openLoads = append(openLoads, stats.OpenReviews)
+		historicalLoads = append(historicalLoads, stats.AssignedReviews)
+	}
+
+	fairness := &models.TeamFairness{
+		TeamName:   teamName,
+		Members:    members,
+		Open:       loadDistribution(openLoads),
+		Historical: loadDistribution(historicalLoads),
+		Threshold:  threshold,
+	}
+	fairness.Imbalanced = len(members) >= 2 &&
+		fairness.Open.Total >= len(members) &&
+		fairness.Open.Gini >= threshold
+
+	return fairness
+}
+
+func loadDistribution(loads []int) models.LoadDistribution {
+	if len(loads) == 0 {
+		return models.LoadDistribution{}
+	}
+
+	sorted := append([]int(nil), loads...)
+	sort.Ints(sorted)
+
+	distribution := models.LoadDistribution{
+		Min: sorted[0],
+		Max: sorted[len(sorted)-1],
+	}
+	for _, load := range sorted {
+		distribution.Total += load
+	}
+
+	n := float64(len(sorted))
+	distribution.Mean = float64(distribution.Total) / n
+
+	var variance, weighted float64
+	for i, load := range sorted {
+		deviation := float64(load) - distribution.Mean
+		variance += deviation * deviation
+		weighted += float64(2*(i+1)-len(sorted)-1) * float64(load)
+	}
+	distribution.StdDev = math.Sqrt(variance / n)
+
+	if distribution.Total > 0 {
+		distribution.Gini = weighted / (n * float64(distribution.Total))
+	}
+
+	switch {
+	case distribution.Min > 0:
+		ratio := float64(distribution.Max) / float64(distribution.Min)
+		distribution.MaxMinRatio = &ratio
+	case distribution.Max == 0:
+		ratio := 1.0
+		distribution.MaxMinRatio = &ratio
+	}
+
+	return distribution
+}
+
+// getOpenReviewAges measures open reviews of the teams on the calendar of teamName.
+func (s *StatisticsService) getOpenReviewAges(teamName string, teamNames []string) (map[string][]time.Duration, error) {
+	assignments, err := s.statsRepository.GetOpenReviewAssignments(teamNames)
+	if err != nil {
+		return nil, err
+	}
+
+	teamCalendar, err := s.calendars.ForTeam(teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	ages := make(map[string][]time.Duration)
+	for _, assignment := range assignments {
+		ages[assignment.UserID] = append(ages[assignment.UserID], teamCalendar.BusinessElapsed(assignment.AssignedAt, now))
+	}
+
+	return ages, nil
+}
+
+func durationPercentiles(durations []time.Duration) models.DurationPercentiles {
+	if len(durations) == 0 {
+		return models.DurationPercentiles{}
+	}
+
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	return models.DurationPercentiles{
+		Count:      len(sorted),
+		P50Minutes: int64(percentile(sorted, 50) / time.Minute),
+		P90Minutes: int64(percentile(sorted, 90) / time.Minute),
+		P99Minutes: int64(percentile(sorted, 99) / time.Minute),
+	}
+}
+
+// percentile uses the nearest-rank method on sorted durations.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func averageDuration(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+
+	var total time.Duration
+	for _, duration := range durations {
+		total += duration
+	}
+
+	return total / time.Duration(len(durations))
+}
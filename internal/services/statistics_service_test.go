@@ -0,0 +1,259 @@
+package services
+
+import (
+	"CodeRewievService/internal/calendar"
+	"CodeRewievService/internal/models"
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestStatisticsPeriods(t *testing.T) {
+	utc := func(year int, month time.Month, day, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	moscow := time.FixedZone("MSK", 3*60*60)
+
+	tests := []struct {
+		name    string
+		groupBy string
+		from    time.Time
+		to      time.Time
+		want    []time.Time
+	}{
+		{
+			"days start at midnight",
+			models.StatisticsGroupByDay,
+			utc(2025, time.March, 3, 10), utc(2025, time.March, 5, 0),
+			[]time.Time{utc(2025, time.March, 3, 0), utc(2025, time.March, 4, 0)},
+		},
+		{
+			"partial last day is included",
+			models.StatisticsGroupByDay,
+			utc(2025, time.March, 3, 0), utc(2025, time.March, 4, 1),
+			[]time.Time{utc(2025, time.March, 3, 0), utc(2025, time.March, 4, 0)},
+		},
+		{
+			"days are cut in UTC",
+			models.StatisticsGroupByDay,
+			time.Date(2025, time.March, 3, 1, 0, 0, 0, moscow), utc(2025, time.March, 3, 12),
+			[]time.Time{utc(2025, time.March, 2, 0), utc(2025, time.March, 3, 0)},
+		},
+		{
+			"weeks start on Monday",
+			models.StatisticsGroupByWeek,
+			utc(2025, time.March, 5, 12), utc(2025, time.March, 18, 0),
+			[]time.Time{utc(2025, time.March, 3, 0), utc(2025, time.March, 10, 0), utc(2025, time.March, 17, 0)},
+		},
+		{
+			"Sunday belongs to the preceding week",
+			models.StatisticsGroupByWeek,
+			utc(2025, time.March, 9, 23), utc(2025, time.March, 10, 0),
+			[]time.Time{utc(2025, time.March, 3, 0)},
+		},
+		{
+			"week across a year boundary",
+			models.StatisticsGroupByWeek,
+			utc(2025, time.January, 1, 0), utc(2025, time.January, 2, 0),
+			[]time.Time{utc(2024, time.December, 30, 0)},
+		},
+		{
+			"months start on the first",
+			models.StatisticsGroupByMonth,
+			utc(2025, time.January, 31, 0), utc(2025, time.March, 1, 0),
+			[]time.Time{utc(2025, time.January, 1, 0), utc(2025, time.February, 1, 0)},
+		},
+		{
+			"empty window",
+			models.StatisticsGroupByDay,
+			utc(2025, time.March, 3, 0), utc(2025, time.March, 3, 0),
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := statisticsPeriods(tt.groupBy, tt.from, tt.to); !slices.Equal(got, tt.want) {
+				t.Fatalf("statisticsPeriods() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatisticsPeriodsStopsPastLimit(t *testing.T) {
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(3, 0, 0)
+
+	if got := len(statisticsPeriods(models.StatisticsGroupByDay, from, to)); got != maxStatisticsBuckets+1 {
+		t.Fatalf("got %d periods, want %d", got, maxStatisticsBuckets+1)
+	}
+}
+
+func TestLoadDistribution(t *testing.T) {
+	ratio := func(r float64) *float64 { return &r }
+
+	tests := []struct {
+		name  string
+		loads []int
+		want  models.LoadDistribution
+	}{
+		{"no members", nil, models.LoadDistribution{}},
+		{"single member", []int{5}, models.LoadDistribution{
+			Total: 5, Mean: 5, Min: 5, Max: 5, MaxMinRatio: ratio(1),
+		}},
+		{"even load", []int{3, 3, 3}, models.LoadDistribution{
+			Total: 9, Mean: 3, Min: 3, Max: 3, MaxMinRatio: ratio(1),
+		}},
+		{"nobody has reviews", []int{0, 0}, models.LoadDistribution{MaxMinRatio: ratio(1)}},
+		{"one member holds everything", []int{0, 6, 0, 0}, models.LoadDistribution{
+			Total: 6, Mean: 1.5, StdDev: math.Sqrt(6.75), Max: 6, Gini: 0.75,
+		}},
+		{"linear load", []int{4, 1, 3, 2}, models.LoadDistribution{
+			Total: 10, Mean: 2.5, StdDev: math.Sqrt(1.25), Min: 1, Max: 4, MaxMinRatio: ratio(4), Gini: 0.25,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loads := slices.Clone(tt.loads)
+			got := loadDistribution(loads)
+
+			if !slices.Equal(loads, tt.loads) {
+				t.Fatalf("loadDistribution reordered its input: %v", loads)
+			}
+			if got.Total != tt.want.Total || got.Min != tt.want.Min || got.Max != tt.want.Max {
+				t.Fatalf("total/min/max = %d/%d/%d, want %d/%d/%d",
+					got.Total, got.Min, got.Max, tt.want.Total, tt.want.Min, tt.want.Max)
+			}
+			for _, field := range []struct {
+				name      string
+				got, want float64
+			}{
+				{"mean", got.Mean, tt.want.Mean},
+				{"stdDev", got.StdDev, tt.want.StdDev},
+				{"gini", got.Gini, tt.want.Gini},
+			} {
+				if math.Abs(field.got-field.want) > 1e-9 {
+					t.Fatalf("%s = %v, want %v", field.name, field.got, field.want)
+				}
+			}
+			switch {
+			case got.MaxMinRatio == nil && tt.want.MaxMinRatio == nil:
+			case got.MaxMinRatio == nil || tt.want.MaxMinRatio == nil || *got.MaxMinRatio != *tt.want.MaxMinRatio:
+				t.Fatalf("maxMinRatio = %v, want %v", got.MaxMinRatio, tt.want.MaxMinRatio)
+			}
+		})
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	minutes := func(n int) []time.Duration {
+		sorted := make([]time.Duration, n)
+		for i := range sorted {
+			sorted[i] = time.Duration(i+1) * time.Minute
+		}
+		return sorted
+	}
+
+	tests := []struct {
+		name   string
+		sorted []time.Duration
+		p      int
+		want   time.Duration
+	}{
+		{"single value", minutes(1), 50, time.Minute},
+		{"single value p99", minutes(1), 99, time.Minute},
+		{"median of three", minutes(3), 50, 2 * time.Minute},
+		{"p90 of three rounds up", minutes(3), 90, 3 * time.Minute},
+		{"median of ten", minutes(10), 50, 5 * time.Minute},
+		{"p90 of ten", minutes(10), 90, 9 * time.Minute},
+		{"p99 of ten is the maximum", minutes(10), 99, 10 * time.Minute},
+		{"p99 of a hundred", minutes(100), 99, 99 * time.Minute},
+		{"p0 is the minimum", minutes(10), 0, time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := percentile(tt.sorted, tt.p); got != tt.want {
+				t.Fatalf("percentile(p%d) = %s, want %s", tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDurationPercentiles(t *testing.T) {
+	tests := []struct {
+		name      string
+		durations []time.Duration
+		want      models.DurationPercentiles
+	}{
+		{"no samples", nil, models.DurationPercentiles{}},
+		{
+			"unsorted samples",
+			[]time.Duration{30 * time.Minute, 10 * time.Minute, 20 * time.Minute},
+			models.DurationPercentiles{Count: 3, P50Minutes: 20, P90Minutes: 30, P99Minutes: 30},
+		},
+		{
+			"partial minutes are truncated",
+			[]time.Duration{90 * time.Second},
+			models.DurationPercentiles{Count: 1, P50Minutes: 1, P90Minutes: 1, P99Minutes: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			durations := slices.Clone(tt.durations)
+			if got := durationPercentiles(durations); got != tt.want {
+				t.Fatalf("durationPercentiles() = %+v, want %+v", got, tt.want)
+			}
+			if !slices.Equal(durations, tt.durations) {
+				t.Fatalf("durationPercentiles reordered its input: %v", durations)
+			}
+		})
+	}
+}
+
+func TestReviewerTurnarounds(t *testing.T) {
+	start := time.Date(2025, time.March, 3, 9, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+
+	reviews := []models.ReviewTurnaroundSample{
+		{UserID: "u2", Username: "bob", AssignedAt: at(0), SubmittedAt: at(30)},
+		{UserID: "u1", Username: "alice", AssignedAt: at(0), SubmittedAt: at(10)},
+		{UserID: "u1", Username: "alice", AssignedAt: at(60), SubmittedAt: at(90)},
+	}
+	reviewed := []models.ReviewerCycleTimeSample{
+		{UserID: "u1", Username: "alice", CreatedAt: at(0), MergedAt: at(120)},
+		{UserID: "u3", Username: "carol", CreatedAt: at(0), MergedAt: at(240)},
+	}
+
+	team, reviewers := reviewerTurnarounds(calendar.AlwaysOpen(), reviews, reviewed)
+
+	wantTeam := models.DurationPercentiles{Count: 3, P50Minutes: 30, P90Minutes: 30, P99Minutes: 30}
+	if team != wantTeam {
+		t.Fatalf("team review turnaround = %+v, want %+v", team, wantTeam)
+	}
+
+	want := []models.ReviewerTurnaround{
+		{
+			UserID:           "u1",
+			Username:         "alice",
+			ReviewTurnaround: models.DurationPercentiles{Count: 2, P50Minutes: 10, P90Minutes: 30, P99Minutes: 30},
+			CycleTime:        models.DurationPercentiles{Count: 1, P50Minutes: 120, P90Minutes: 120, P99Minutes: 120},
+		},
+		{
+			UserID:           "u2",
+			Username:         "bob",
+			ReviewTurnaround: models.DurationPercentiles{Count: 1, P50Minutes: 30, P90Minutes: 30, P99Minutes: 30},
+		},
+		{
+			UserID:    "u3",
+			Username:  "carol",
+			CycleTime: models.DurationPercentiles{Count: 1, P50Minutes: 240, P90Minutes: 240, P99Minutes: 240},
+		},
+	}
+	if !slices.Equal(reviewers, want) {
+		t.Fatalf("reviewers = %+v, want %+v", reviewers, want)
+	}
+}
@@ -1,38 +1,60 @@
 package services
 
 import (
+	"CodeRewievService/internal/audit"
+	"CodeRewievService/internal/events"
 	"CodeRewievService/internal/models"
 	"CodeRewievService/internal/repository"
+	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+// errTeamDeactivationDryRun rolls back the transaction of a dry run after the result has been computed.
+var errTeamDeactivationDryRun = errors.New("team deactivation dry run")
+
 const (
 	massDeactivationWarningThreshold = 100 * time.Millisecond
+	maxTeamNameLength                = 100
 )
 
 type TeamService struct {
 	teamRepository *repository.TeamRepository
 	prRepository   *repository.PullRequestRepository
+	prService      *PullRequestService
+	notifications  *NotificationService
+	audit          *AuditService
+	bus            *events.Bus
 	logger         *slog.Logger
 }
 
 func NewTeamService(
 	teamRepository *repository.TeamRepository,
 	prRepository *repository.PullRequestRepository,
+	prService *PullRequestService,
+	notifications *NotificationService,
+	audit *AuditService,
+	bus *events.Bus,
 	logger *slog.Logger,
 ) *TeamService {
 	return &TeamService{
 		teamRepository: teamRepository,
 		prRepository:   prRepository,
+		prService:      prService,
+		notifications:  notifications,
+		audit:          audit,
+		bus:            bus,
 		logger:         logger,
 	}
 }
 
-func (s *TeamService) Add(team *models.Team) error {
+func (s *TeamService) Add(ctx context.Context, team *models.Team) error {
 	if err := s.validateTeamInput(team); err != nil {
 		return err
 	}
@@ -41,10 +63,21 @@ func (s *TeamService) Add(team *models.Team) error {
 		return err
 	}
 
-	return s.teamRepository.CreateWithUsers(team, team.Members)
+	return s.teamRepository.Transaction(func(tx *gorm.DB) error {
+		if err := s.checkParentInTx(tx, team.TeamName, team.ParentTeam); err != nil {
+			return err
+		}
+
+		if err := s.teamRepository.CreateWithUsersInTx(tx, team, team.Members); err != nil {
+			return err
+		}
+
+		return s.audit.RecordInTx(ctx, tx, models.AuditTeamCreate, models.AuditEntityTeam, team.TeamName, nil, team)
+	})
 }
 
-func (s *TeamService) Get(teamName string) (*models.Team, error) {
+// Get returns the team with its members; with includeSubTeams members of all sub-teams are included as well.
+func (s *TeamService) Get(teamName string, includeSubTeams bool) (*models.Team, error) {
 	if teamName == "" {
 		return nil, errors.New("team name cannot be empty")
 	}
@@ -57,7 +90,15 @@ func (s *TeamService) Get(teamName string) (*models.Team, error) {
 		return nil, err
 	}
 
-	users, err := s.teamRepository.GetUsersByTeam(team.TeamName)
+	teamNames := []string{team.TeamName}
+	if includeSubTeams {
+		if team.SubTeams, err = s.teamRepository.FindSubTeamNames(team.TeamName); err != nil {
+			return nil, err
+		}
+		teamNames = append(teamNames, team.SubTeams...)
+	}
+
+	users, err := s.teamRepository.GetUsersByTeams(teamNames)
 	if err != nil {
 		return nil, err
 	}
@@ -66,30 +107,542 @@ func (s *TeamService) Get(teamName string) (*models.Team, error) {
 	return team, nil
 }
 
-func (s *TeamService) MassDeactivateTeamUsers(teamName string) error {
-	startTime := time.Now()
+// SetParent moves the team under another team or, with an empty parent, to the top level. A team cannot be
+// placed under itself or one of its sub-teams.
+func (s *TeamService) SetParent(ctx context.Context, req *models.RequestSetParentTeam) (*models.Team, error) {
+	if req.TeamName == "" {
+		return nil, errors.New("team name cannot be empty")
+	}
+
+	var team *models.Team
+	err := s.teamRepository.Transaction(func(tx *gorm.DB) error {
+		var err error
+		team, err = s.teamRepository.FindByNameForUpdateInTx(tx, req.TeamName)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.ErrTeamNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if team.ArchivedAt != nil {
+			return models.ErrTeamArchived
+		}
+
+		if err := s.checkParentInTx(tx, req.TeamName, req.ParentTeam); err != nil {
+			return err
+		}
+
+		before := *team
+		team.ParentTeam = req.ParentTeam
+		if err := s.teamRepository.SetParentInTx(tx, team.TeamName, team.ParentTeam); err != nil {
+			return err
+		}
+
+		return s.audit.RecordInTx(ctx, tx, models.AuditTeamSetParent, models.AuditEntityTeam, team.TeamName, before, team)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
+func (s *TeamService) checkParentInTx(tx *gorm.DB, teamName, parentTeam string) error {
+	if parentTeam == "" {
+		return nil
+	}
+
+	if parentTeam == teamName {
+		return fmt.Errorf("%w: a team cannot be its own parent", models.ErrInvalidTeamHierarchy)
+	}
+
+	parent, err := s.teamRepository.FindByNameForUpdateInTx(tx, parentTeam)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.ErrTeamNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if parent.ArchivedAt != nil {
+		return models.ErrTeamArchived
+	}
+
+	ancestors, err := s.teamRepository.FindAncestorNamesInTx(tx, parentTeam)
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(ancestors, teamName) {
+		return fmt.Errorf("%w: %s is a sub-team of %s", models.ErrInvalidTeamHierarchy, parentTeam, teamName)
+	}
+	return nil
+}
+
+func (s *TeamService) List(includeArchived bool) ([]models.TeamSummary, error) {
+	return s.teamRepository.List(includeArchived)
+}
+
+// Archive hides the team from listings and reviewer selection while keeping it and its history. Members who
+// belong to no other active team are deactivated, and the team's open PRs are handled per the policy; reviews
+// of the team's members that they can no longer hold are reassigned in both cases. Sub-teams have to be
+// archived first.
+func (s *TeamService) Archive(ctx context.Context, req *models.RequestArchiveTeam) (*models.TeamArchiveResult, error) {
+	if req.TeamName == "" {
+		return nil, errors.New("team name cannot be empty")
+	}
+
+	if req.Policy == "" {
+		req.Policy = models.TeamArchivePolicyReassign
+	}
+	if !models.IsKnownTeamArchivePolicy(req.Policy) {
+		return nil, fmt.Errorf("%w: policy must be reassign or close", models.ErrInvalidArchivePolicy)
+	}
+
+	result := &models.TeamArchiveResult{
+		TeamName:             req.TeamName,
+		Policy:               req.Policy,
+		ClosedPullRequestIDs: []string{},
+	}
+
+	err := s.teamRepository.Transaction(func(tx *gorm.DB) error {
+		team, err := s.teamRepository.FindByNameForUpdateInTx(tx, req.TeamName)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.ErrTeamNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if team.ArchivedAt != nil {
+			return models.ErrTeamArchived
+		}
+
+		subTeams, err := s.teamRepository.FindChildNamesInTx(tx, []string{team.TeamName})
+		if err != nil {
+			return err
+		}
+		if len(subTeams) > 0 {
+			return fmt.Errorf("%w: archive sub-teams %s first", models.ErrInvalidTeamHierarchy, strings.Join(subTeams, ", "))
+		}
+
+		memberIDs, err := s.teamRepository.FindMemberIDsInTx(tx, []string{team.TeamName})
+		if err != nil {
+			return err
+		}
+
+		before := *team
+		result.ArchivedAt = time.Now()
+		team.ArchivedAt = &result.ArchivedAt
+		if err := s.teamRepository.ArchiveInTx(tx, team.TeamName, result.ArchivedAt); err != nil {
+			return err
+		}
+
+		if result.DeactivatedUserIDs, err = s.teamRepository.DeactivateExclusiveMembersInTx(tx, team.TeamName); err != nil {
+			return err
+		}
+
+		if req.Policy == models.TeamArchivePolicyClose {
+			if err := s.closeOpenPRsInTx(ctx, tx, team.TeamName, result); err != nil {
+				return err
+			}
+		}
+
+		if result.ReassignedReviews, err = s.prService.ReassignReviewsInTx(ctx, tx, memberIDs); err != nil {
+			return err
+		}
+
+		if err := s.bus.PublishInTx(ctx, tx, events.TeamArchived{
+			TeamName:             team.TeamName,
+			Policy:               result.Policy,
+			DeactivatedUserIDs:   result.DeactivatedUserIDs,
+			ClosedPullRequestIDs: result.ClosedPullRequestIDs,
+		}); err != nil {
+			return err
+		}
+
+		if err := s.notifications.EnqueueInTx(tx, teamArchivedEvent(team.TeamName), result.DeactivatedUserIDs...); err != nil {
+			return err
+		}
+
+		return s.audit.RecordInTx(ctx, tx, models.AuditTeamArchive, models.AuditEntityTeam, team.TeamName, before, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if result.DeactivatedUserIDs == nil {
+		result.DeactivatedUserIDs = []string{}
+	}
+	if result.ReassignedReviews == nil {
+		result.ReassignedReviews = []models.ReviewReassignment{}
+	}
+
+	s.bus.Publish(ctx, append([]events.Event{events.TeamArchived{
+		TeamName:             result.TeamName,
+		Policy:               result.Policy,
+		DeactivatedUserIDs:   result.DeactivatedUserIDs,
+		ClosedPullRequestIDs: result.ClosedPullRequestIDs,
+	}}, reviewerReplacedEvents(result.ReassignedReviews)...)...)
+
+	return result, nil
+}
+
+// Rename gives the team a new name, keeping its members, settings, PR attribution and history.
+func (s *TeamService) Rename(ctx context.Context, req *models.RequestRenameTeam) (*models.Team, error) {
+	req.NewName = strings.TrimSpace(req.NewName)
+	if req.TeamName == "" || req.NewName == "" {
+		return nil, fmt.Errorf("%w: teamName and newName are required", models.ErrInvalidTeamName)
+	}
+	if len(req.NewName) > maxTeamNameLength {
+		return nil, fmt.Errorf("%w: newName is longer than %d characters", models.ErrInvalidTeamName, maxTeamNameLength)
+	}
+
+	var team *models.Team
+	err := s.teamRepository.Transaction(func(tx *gorm.DB) error {
+		var err error
+		team, err = s.teamRepository.FindByNameForUpdateInTx(tx, req.TeamName)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.ErrTeamNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if req.NewName == team.TeamName {
+			return nil
+		}
+
+		_, err = s.teamRepository.FindByNameForUpdateInTx(tx, req.NewName)
+		if err == nil {
+			return models.ErrTeamAlreadyExists
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		before := *team
+		if err := s.teamRepository.RenameInTx(tx, team.TeamName, req.NewName); err != nil {
+			return err
+		}
+		team.TeamName = req.NewName
+
+		if err := s.bus.PublishInTx(ctx, tx, events.TeamRenamed{
+			OldName: before.TeamName,
+			NewName: team.TeamName,
+		}); err != nil {
+			return err
+		}
+
+		return s.audit.RecordInTx(ctx, tx, models.AuditTeamRename, models.AuditEntityTeam, team.TeamName, before, team)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if req.TeamName != team.TeamName {
+		s.bus.Publish(ctx, events.TeamRenamed{OldName: req.TeamName, NewName: team.TeamName})
+	}
+
+	return team, nil
+}
+
+// Delete removes a team without members and sub-teams, archived or not.
+func (s *TeamService) Delete(ctx context.Context, teamName string) error {
+	if teamName == "" {
+		return errors.New("team name cannot be empty")
+	}
 
 	return s.teamRepository.Transaction(func(tx *gorm.DB) error {
+		team, err := s.teamRepository.FindByNameForUpdateInTx(tx, teamName)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.ErrTeamNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		dependents, err := s.teamRepository.CountDependentsInTx(tx, teamName)
+		if err != nil {
+			return err
+		}
+		if dependents > 0 {
+			return models.ErrTeamNotEmpty
+		}
+
+		if err := s.teamRepository.DeleteInTx(tx, teamName); err != nil {
+			return err
+		}
+
+		return s.audit.RecordInTx(ctx, tx, models.AuditTeamDelete, models.AuditEntityTeam, teamName, team, nil)
+	})
+}
+
+func (s *TeamService) closeOpenPRsInTx(ctx context.Context, tx *gorm.DB, teamName string, result *models.TeamArchiveResult) error {
+	openPRs, err := s.teamRepository.GetOpenPRsByTeam(tx, teamName)
+	if err != nil {
+		return err
+	}
+
+	for i := range openPRs {
+		if err := s.prService.CloseInTx(ctx, tx, &openPRs[i]); err != nil {
+			return err
+		}
+		result.ClosedPullRequestIDs = append(result.ClosedPullRequestIDs, openPRs[i].PullRequestID)
+	}
+
+	return nil
+}
+
+func (s *TeamService) MassDeactivateTeamUsers(
+	ctx context.Context,
+	teamName string,
+	dryRun bool,
+) (*models.TeamDeactivationResult, error) {
+	startTime := time.Now()
+	result := &models.TeamDeactivationResult{
+		TeamName:               teamName,
+		DryRun:                 dryRun,
+		DeactivatedUserIDs:     []string{},
+		AffectedPullRequestIDs: []string{},
+		RemovedReviews:         []models.ReviewReassignment{},
+		HeldReviews:            []models.ReviewReassignment{},
+	}
+
+	err := s.teamRepository.Transaction(func(tx *gorm.DB) error {
 		if err := s.validateTeamExists(teamName); err != nil {
 			return err
 		}
 
-		rowsAffected, err := s.teamRepository.DeactivateUsers(teamName)
+		deactivatedUserIDs, err := s.teamRepository.DeactivateUsersInTx(tx, teamName)
 		if err != nil {
 			return err
 		}
 
-		if rowsAffected == 0 {
+		if len(deactivatedUserIDs) == 0 {
 			return nil
 		}
+		result.DeactivatedUserIDs = deactivatedUserIDs
+
+		affectedPRIDs, removed, err := s.removeReviewersFromOpenPRs(ctx, tx, teamName, result)
+		if err != nil {
+			return err
+		}
+		result.AffectedPullRequestIDs = affectedPRIDs
+
+		if dryRun {
+			held, err := s.prRepository.FindOpenReviewsByReviewersInTx(tx, deactivatedUserIDs)
+			if err != nil {
+				return err
+			}
+			for _, reviewer := range held {
+				result.HeldReviews = append(result.HeldReviews, models.ReviewReassignment{
+					PullRequestID: reviewer.PullRequestID,
+					OldReviewerID: reviewer.UserID,
+				})
+			}
+			return errTeamDeactivationDryRun
+		}
+
+		if result.DeactivationID, err = s.snapshotDeactivationInTx(ctx, tx, teamName, deactivatedUserIDs,
+			removed); err != nil {
+			return err
+		}
+
+		if err := s.bus.PublishInTx(ctx, tx, events.TeamDeactivated{
+			TeamName:               teamName,
+			DeactivatedUserIDs:     deactivatedUserIDs,
+			AffectedPullRequestIDs: affectedPRIDs,
+		}); err != nil {
+			return err
+		}
+
+		if err := s.notifications.EnqueueInTx(tx, teamDeactivatedEvent(teamName), deactivatedUserIDs...); err != nil {
+			return err
+		}
 
-		if err := s.removeReviewersFromOpenPRs(tx, teamName); err != nil {
+		if err := s.audit.RecordInTx(ctx, tx, models.AuditTeamMassDeactivate, models.AuditEntityTeam, teamName,
+			models.TeamDeactivationSnapshot{TeamName: teamName, ActiveUserIDs: deactivatedUserIDs},
+			models.TeamDeactivationSnapshot{
+				TeamName:               teamName,
+				DeactivationID:         result.DeactivationID,
+				DeactivatedUserIDs:     deactivatedUserIDs,
+				AffectedPullRequestIDs: affectedPRIDs,
+			},
+		); err != nil {
 			return err
 		}
 
 		s.logPerformanceWarning(teamName, startTime)
 		return nil
 	})
+	if err != nil && !errors.Is(err, errTeamDeactivationDryRun) {
+		return nil, err
+	}
+
+	if !dryRun && len(result.DeactivatedUserIDs) > 0 {
+		s.bus.Publish(ctx, events.TeamDeactivated{
+			TeamName:               teamName,
+			DeactivatedUserIDs:     result.DeactivatedUserIDs,
+			AffectedPullRequestIDs: result.AffectedPullRequestIDs,
+		})
+	}
+
+	return result, nil
+}
+
+// Reactivate reverts a mass deactivation: its users are activated again and the reviews it removed are restored
+// where nobody changed them since, see PullRequestService.RestoreReviewInTx. A deactivation is reverted once.
+func (s *TeamService) Reactivate(ctx context.Context, deactivationID int64) (*models.TeamReactivationResult, error) {
+	result := &models.TeamReactivationResult{
+		DeactivationID:  deactivationID,
+		RestoredReviews: []models.ReviewReassignment{},
+		SkippedReviews:  []models.TeamDeactivationReview{},
+	}
+
+	err := s.teamRepository.Transaction(func(tx *gorm.DB) error {
+		deactivation, err := s.teamRepository.FindDeactivationForUpdateInTx(tx, deactivationID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.ErrDeactivationNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if deactivation.RestoredAt != nil {
+			return models.ErrDeactivationRestored
+		}
+		result.TeamName = deactivation.TeamName
+
+		userIDs, err := s.teamRepository.FindDeactivationUserIDsInTx(tx, deactivationID)
+		if err != nil {
+			return err
+		}
+
+		if result.ReactivatedUserIDs, err = s.teamRepository.ActivateUsersInTx(tx, userIDs); err != nil {
+			return err
+		}
+
+		if err := s.restoreReviewsInTx(ctx, tx, deactivationID, result); err != nil {
+			return err
+		}
+
+		before := *deactivation
+		restoredAt := time.Now()
+		deactivation.RestoredAt = &restoredAt
+		if err := s.teamRepository.MarkDeactivationRestoredInTx(tx, deactivationID, restoredAt); err != nil {
+			return err
+		}
+
+		return s.audit.RecordInTx(ctx, tx, models.AuditTeamReactivate, models.AuditEntityTeam, deactivation.TeamName,
+			before, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	published := make([]events.Event, len(result.RestoredReviews))
+	for i, restored := range result.RestoredReviews {
+		if restored.OldReviewerID == "" {
+			published[i] = events.ReviewerAssigned{
+				PullRequestID: restored.PullRequestID,
+				ReviewerID:    restored.NewReviewerID,
+				Reason:        models.ReviewerChangeTeamReactivated,
+			}
+			continue
+		}
+
+		published[i] = events.ReviewerReplaced{
+			PullRequestID: restored.PullRequestID,
+			OldReviewerID: restored.OldReviewerID,
+			NewReviewerID: restored.NewReviewerID,
+			Reason:        models.ReviewerChangeTeamReactivated,
+		}
+	}
+	s.bus.Publish(ctx, published...)
+
+	return result, nil
+}
+
+func (s *TeamService) restoreReviewsInTx(
+	ctx context.Context,
+	tx *gorm.DB,
+	deactivationID int64,
+	result *models.TeamReactivationResult,
+) error {
+	reviews, err := s.teamRepository.FindDeactivationReviewsInTx(tx, deactivationID)
+	if err != nil {
+		return err
+	}
+
+	prIDs := make([]string, 0, len(reviews))
+	for _, review := range reviews {
+		if !slices.Contains(prIDs, review.PullRequestID) {
+			prIDs = append(prIDs, review.PullRequestID)
+		}
+	}
+
+	openPRs, err := s.prRepository.FindOpenByIDsForUpdateInTx(tx, prIDs)
+	if err != nil {
+		return err
+	}
+
+	openByID := make(map[string]*models.PullRequest, len(openPRs))
+	for i := range openPRs {
+		openByID[openPRs[i].PullRequestID] = &openPRs[i]
+	}
+
+	for i := range reviews {
+		review := &reviews[i]
+
+		restored := false
+		if pr, ok := openByID[review.PullRequestID]; ok {
+			if restored, err = s.prService.RestoreReviewInTx(ctx, tx, pr, review); err != nil {
+				return err
+			}
+		}
+
+		if !restored {
+			result.SkippedReviews = append(result.SkippedReviews, *review)
+			continue
+		}
+
+		result.RestoredReviews = append(result.RestoredReviews, models.ReviewReassignment{
+			PullRequestID: review.PullRequestID,
+			OldReviewerID: review.ReplacedBy,
+			NewReviewerID: review.UserID,
+		})
+	}
+
+	return nil
+}
+
+// snapshotDeactivationInTx records the deactivated users and the review assignments the deactivation removed and
+// returns the deactivation ID.
+func (s *TeamService) snapshotDeactivationInTx(
+	ctx context.Context,
+	tx *gorm.DB,
+	teamName string,
+	userIDs []string,
+	removed []models.PullRequestReviewer,
+) (int64, error) {
+	reviews := make([]models.TeamDeactivationReview, len(removed))
+	for i, reviewer := range removed {
+		reviews[i] = models.TeamDeactivationReview{
+			PullRequestID: reviewer.PullRequestID,
+			UserID:        reviewer.UserID,
+			TeamName:      reviewer.TeamName,
+			AssignedAt:    reviewer.AssignedAt,
+		}
+	}
+
+	deactivation := &models.TeamDeactivation{TeamName: teamName, Actor: audit.Actor(ctx)}
+	if err := s.teamRepository.CreateDeactivationInTx(tx, deactivation, userIDs, reviews); err != nil {
+		return 0, err
+	}
+
+	return deactivation.ID, nil
 }
 
 func (s *TeamService) validateTeamInput(team *models.Team) error {
@@ -99,6 +652,14 @@ func (s *TeamService) validateTeamInput(team *models.Team) error {
 	if team.TeamName == "" {
 		return errors.New("team name cannot be empty")
 	}
+
+	seen := make(map[string]bool, len(team.Members))
+	for _, member := range team.Members {
+		if seen[member.UserID] {
+			return fmt.Errorf("%w: %s", models.ErrDuplicateTeamMember, member.UserID)
+		}
+		seen[member.UserID] = true
+	}
 	return nil
 }
 
@@ -121,18 +682,42 @@ func (s *TeamService) validateTeamExists(teamName string) error {
 	return err
 }
 
-func (s *TeamService) removeReviewersFromOpenPRs(tx *gorm.DB, teamName string) error {
+// removeReviewersFromOpenPRs withdraws every review of the team's open PRs and returns the PR IDs and the
+// removed assignments.
+func (s *TeamService) removeReviewersFromOpenPRs(
+	ctx context.Context,
+	tx *gorm.DB,
+	teamName string,
+	result *models.TeamDeactivationResult,
+) ([]string, []models.PullRequestReviewer, error) {
 	openPRs, err := s.teamRepository.GetOpenPRsByTeam(tx, teamName)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	if len(openPRs) == 0 {
-		return nil
+		return []string{}, nil, nil
 	}
 
 	prIDs := s.extractPRIDs(openPRs)
-	return s.prRepository.DeleteReviewersByPRIDsInTx(tx, prIDs)
+	removed, err := s.prRepository.DeleteReviewersByPRIDsInTx(tx, prIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	timeline := make([]models.PullRequestEvent, len(removed))
+	for i, reviewer := range removed {
+		timeline[i] = newPullRequestEvent(ctx, reviewer.PullRequestID, models.PullRequestEventReviewerRemoved)
+		timeline[i].OldReviewerID = reviewer.UserID
+		timeline[i].Reason = models.ReviewerChangeTeamDeactivated
+
+		result.RemovedReviews = append(result.RemovedReviews, models.ReviewReassignment{
+			PullRequestID: reviewer.PullRequestID,
+			OldReviewerID: reviewer.UserID,
+		})
+	}
+
+	return prIDs, removed, s.prRepository.CreateEventsInTx(tx, timeline)
 }
 
 func (s *TeamService) extractPRIDs(prs []models.PullRequest) []string {
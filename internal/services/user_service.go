@@ -1,24 +1,62 @@
 package services
 
 import (
+	"CodeRewievService/internal/calendar"
+	"CodeRewievService/internal/events"
 	"CodeRewievService/internal/models"
 	"CodeRewievService/internal/repository"
+	"context"
 	"errors"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
 
+const (
+	maxBulkSetIsActiveUsers         = 1000
+	bulkSetIsActiveWarningThreshold = 100 * time.Millisecond
+	defaultUserPageLimit            = 50
+	maxUserPageLimit                = 500
+)
+
 type UserService struct {
 	userRepository *repository.UserRepository
+	teamRepository *repository.TeamRepository
+	prService      *PullRequestService
+	calendars      *CalendarService
+	notifications  *NotificationService
+	audit          *AuditService
+	bus            *events.Bus
+	logger         *slog.Logger
 }
 
-func NewUserService(userRepository *repository.UserRepository) *UserService {
+func NewUserService(
+	userRepository *repository.UserRepository,
+	teamRepository *repository.TeamRepository,
+	prService *PullRequestService,
+	calendars *CalendarService,
+	notifications *NotificationService,
+	audit *AuditService,
+	bus *events.Bus,
+	logger *slog.Logger,
+) *UserService {
 	return &UserService{
 		userRepository: userRepository,
+		teamRepository: teamRepository,
+		prService:      prService,
+		calendars:      calendars,
+		notifications:  notifications,
+		audit:          audit,
+		bus:            bus,
+		logger:         logger,
 	}
 }
 
-func (s *UserService) SetIsActive(user *models.User) (*models.User, error) {
+func (s *UserService) SetIsActive(ctx context.Context, user *models.User) (*models.User, error) {
 	if err := s.validateUserInput(user); err != nil {
 		return nil, err
 	}
@@ -31,15 +69,273 @@ func (s *UserService) SetIsActive(user *models.User) (*models.User, error) {
 		return nil, err
 	}
 
+	if existingUser.IsActive == user.IsActive {
+		return existingUser, nil
+	}
+
+	before := *existingUser
+	deactivated := existingUser.IsActive && !user.IsActive
 	existingUser.IsActive = user.IsActive
 
-	if err := s.userRepository.Update(existingUser); err != nil {
+	err = s.userRepository.Transaction(func(tx *gorm.DB) error {
+		if err := s.userRepository.UpdateInTx(tx, existingUser); err != nil {
+			return err
+		}
+
+		if err := s.audit.RecordInTx(ctx, tx, models.AuditUserSetIsActive, models.AuditEntityUser,
+			existingUser.UserID, before, existingUser); err != nil {
+			return err
+		}
+
+		if !deactivated {
+			return nil
+		}
+
+		return s.notifications.EnqueueInTx(tx, userDeactivatedEvent(existingUser), existingUser.UserID)
+	})
+	if err != nil {
 		return nil, err
 	}
 
+	if deactivated {
+		s.bus.Publish(ctx, events.UserDeactivated{UserID: existingUser.UserID, TeamName: existingUser.TeamName})
+	}
+
 	return existingUser, nil
 }
 
+// BulkSetIsActive sets the state of many users in one transaction. Open reviews of deactivated users are
+// reassigned like after a membership change. Repeated IDs are collapsed into one result and empty IDs are
+// skipped; unknown IDs are reported as not found, not rejected.
+func (s *UserService) BulkSetIsActive(
+	ctx context.Context,
+	req *models.RequestBulkSetIsActive,
+) (*models.ResponseBulkSetIsActive, error) {
+	userIDs := uniqueUserIDs(req.UserIDs)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("%w: userIds cannot be empty", models.ErrInvalidBulkRequest)
+	}
+	if len(userIDs) > maxBulkSetIsActiveUsers {
+		return nil, fmt.Errorf("%w: at most %d users can be changed at once", models.ErrInvalidBulkRequest, maxBulkSetIsActiveUsers)
+	}
+
+	startTime := time.Now()
+	response := &models.ResponseBulkSetIsActive{
+		IsActive: req.IsActive,
+		Results:  make([]models.BulkUserResult, len(userIDs)),
+	}
+
+	var deactivated []*models.User
+	err := s.userRepository.Transaction(func(tx *gorm.DB) error {
+		users, err := s.userRepository.FindByIDsForUpdateInTx(tx, userIDs)
+		if err != nil {
+			return err
+		}
+
+		usersByID := make(map[string]*models.User, len(users))
+		for i := range users {
+			usersByID[users[i].UserID] = &users[i]
+		}
+
+		var changedIDs []string
+		var changes []models.AuditChange
+		for i, userID := range userIDs {
+			response.Results[i] = models.BulkUserResult{UserID: userID, Status: models.BulkUserChanged}
+
+			user, ok := usersByID[userID]
+			switch {
+			case !ok:
+				response.Results[i].Status = models.BulkUserNotFound
+				continue
+			case user.IsActive == req.IsActive:
+				response.Results[i].Status = models.BulkUserUnchanged
+				continue
+			}
+
+			before := *user
+			user.IsActive = req.IsActive
+			changedIDs = append(changedIDs, userID)
+			changes = append(changes, models.AuditChange{EntityID: userID, Before: before, After: *user})
+			if !req.IsActive {
+				deactivated = append(deactivated, user)
+			}
+		}
+
+		if err := s.userRepository.SetIsActiveInTx(tx, changedIDs, req.IsActive); err != nil {
+			return err
+		}
+
+		if err := s.audit.RecordManyInTx(ctx, tx, models.AuditUserSetIsActive, models.AuditEntityUser, changes); err != nil {
+			return err
+		}
+
+		if len(deactivated) == 0 {
+			return nil
+		}
+
+		if response.ReassignedReviews, err = s.prService.ReassignReviewsInTx(ctx, tx, changedIDs); err != nil {
+			return err
+		}
+
+		notices := make(map[string]models.NotificationEvent, len(deactivated))
+		for _, user := range deactivated {
+			notices[user.UserID] = userDeactivatedEvent(user)
+		}
+		return s.notifications.EnqueueEachInTx(tx, notices)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if response.ReassignedReviews == nil {
+		response.ReassignedReviews = []models.ReviewReassignment{}
+	}
+
+	published := make([]events.Event, 0, len(deactivated)+len(response.ReassignedReviews))
+	for _, user := range deactivated {
+		published = append(published, events.UserDeactivated{UserID: user.UserID, TeamName: user.TeamName})
+	}
+	s.bus.Publish(ctx, append(published, reviewerReplacedEvents(response.ReassignedReviews)...)...)
+
+	if duration := time.Since(startTime); duration > bulkSetIsActiveWarningThreshold {
+		s.logger.Warn("BulkSetIsActive execution time exceeded threshold",
+			"users", len(userIDs),
+			"duration", duration,
+			"threshold", bulkSetIsActiveWarningThreshold,
+		)
+	}
+
+	return response, nil
+}
+
+func (s *UserService) Get(userID string) (*models.ResponseUser, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("%w: user_id cannot be empty", models.ErrInvalidUserProfile)
+	}
+
+	user, err := s.userRepository.FindByID(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, models.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	teams, err := s.teamRepository.FindTeamNamesByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if teams == nil {
+		teams = []string{}
+	}
+
+	return &models.ResponseUser{User: *user, Teams: teams}, nil
+}
+
+func (s *UserService) List(filter models.UserFilter) (*models.ResponseUserList, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultUserPageLimit
+	}
+	if filter.Limit > maxUserPageLimit {
+		filter.Limit = maxUserPageLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	users, total, err := s.userRepository.List(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ResponseUserList{
+		Users:  users,
+		Total:  total,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}, nil
+}
+
+// Update changes the user's name and profile attributes; team membership and activity have their own endpoints.
+func (s *UserService) Update(ctx context.Context, req *models.RequestUpdateUser) (*models.User, error) {
+	if req.UserID == "" {
+		return nil, fmt.Errorf("%w: userId cannot be empty", models.ErrInvalidUserProfile)
+	}
+
+	var user *models.User
+	err := s.userRepository.Transaction(func(tx *gorm.DB) error {
+		var err error
+		user, err = s.userRepository.FindByIDForUpdateInTx(tx, req.UserID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.ErrUserNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		before := *user
+		if err := applyUserProfile(user, req); err != nil {
+			return err
+		}
+
+		if *user == before {
+			return nil
+		}
+
+		if err := s.userRepository.UpdateInTx(tx, user); err != nil {
+			return err
+		}
+
+		return s.audit.RecordInTx(ctx, tx, models.AuditUserUpdate, models.AuditEntityUser, user.UserID, before, user)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func applyUserProfile(user *models.User, req *models.RequestUpdateUser) error {
+	if req.Username != nil {
+		username := strings.TrimSpace(*req.Username)
+		if username == "" {
+			return fmt.Errorf("%w: username cannot be empty", models.ErrInvalidUserProfile)
+		}
+		user.Username = username
+	}
+
+	if req.Email != nil {
+		email := strings.TrimSpace(*req.Email)
+		if email != "" {
+			address, err := mail.ParseAddress(email)
+			if err != nil || address.Address != email {
+				return fmt.Errorf("%w: invalid email %q", models.ErrInvalidUserProfile, email)
+			}
+		}
+		user.Email = email
+	}
+
+	if req.DisplayName != nil {
+		user.DisplayName = strings.TrimSpace(*req.DisplayName)
+	}
+
+	if req.ChatHandle != nil {
+		user.ChatHandle = strings.TrimSpace(*req.ChatHandle)
+	}
+
+	if req.Timezone != nil {
+		timezone := strings.TrimSpace(*req.Timezone)
+		if timezone != "" {
+			if _, err := time.LoadLocation(timezone); err != nil {
+				return fmt.Errorf("%w: unknown timezone %q", models.ErrInvalidUserProfile, timezone)
+			}
+		}
+		user.Timezone = timezone
+	}
+
+	return nil
+}
+
 func (s *UserService) GetReview(userID string) (*models.UserReview, error) {
 	if userID == "" {
 		return nil, errors.New("user_id cannot be empty")
@@ -54,7 +350,7 @@ func (s *UserService) GetReview(userID string) (*models.UserReview, error) {
 		return nil, err
 	}
 
-	return s.buildUserReview(userID, pullRequests), nil
+	return s.buildUserReview(userID, pullRequests)
 }
 
 func (s *UserService) validateUserInput(user *models.User) error {
@@ -67,6 +363,20 @@ func (s *UserService) validateUserInput(user *models.User) error {
 	return nil
 }
 
+func uniqueUserIDs(userIDs []string) []string {
+	seen := make(map[string]bool, len(userIDs))
+	unique := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if userID == "" || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		unique = append(unique, userID)
+	}
+
+	return unique
+}
+
 func (s *UserService) validateUserExists(userID string) error {
 	_, err := s.userRepository.FindByID(userID)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -75,14 +385,30 @@ func (s *UserService) validateUserExists(userID string) error {
 	return err
 }
 
-func (s *UserService) buildUserReview(userID string, pullRequests []models.PullRequest) *models.UserReview {
+func (s *UserService) buildUserReview(userID string, pullRequests []models.PullRequest) (*models.UserReview, error) {
+	now := time.Now()
+	teamCalendars := make(map[string]*calendar.Calendar)
 	pullRequestDTOs := make([]models.PullRequestDTO, len(pullRequests))
-	for i, pr := range pullRequests {
+
+	for i := range pullRequests {
+		pr := &pullRequests[i]
+
+		teamName := pr.OwnerTeam()
+		teamCalendar, ok := teamCalendars[teamName]
+		if !ok {
+			var err error
+			if teamCalendar, err = s.calendars.ForTeam(teamName); err != nil {
+				return nil, err
+			}
+			teamCalendars[teamName] = teamCalendar
+		}
+
+		pr.BusinessAge = s.calendars.PullRequestAge(teamCalendar, pr, now)
 		pullRequestDTOs[i] = pr.ToResponse()
 	}
 
 	return &models.UserReview{
 		UserID:       userID,
 		PullRequests: pullRequestDTOs,
-	}
+	}, nil
 }
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"CodeRewievService/internal/events"
+	"CodeRewievService/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type reviewersAssignedData struct {
+	PullRequest models.PullRequestDTO `json:"pullRequest"`
+	ReviewerIDs []string              `json:"reviewerIds"`
+}
+
+type reviewerReplacedData struct {
+	PullRequestID string `json:"pullRequestId"`
+	OldReviewerID string `json:"oldReviewerId"`
+	NewReviewerID string `json:"newReviewerId"`
+}
+
+type pullRequestMergedData struct {
+	PullRequest models.PullRequestDTO `json:"pullRequest"`
+}
+
+type teamArchivedData struct {
+	TeamName             string   `json:"teamName"`
+	Policy               string   `json:"policy"`
+	DeactivatedUserIDs   []string `json:"deactivatedUserIds"`
+	ClosedPullRequestIDs []string `json:"closedPullRequestIds"`
+}
+
+type teamRenamedData struct {
+	OldName string `json:"oldName"`
+	NewName string `json:"newName"`
+}
+
+type loadImbalancedData struct {
+	TeamName  string                  `json:"teamName"`
+	Gini      float64                 `json:"gini"`
+	Threshold float64                 `json:"threshold"`
+	Open      models.LoadDistribution `json:"open"`
+	Members   []models.MemberLoad     `json:"members"`
+}
+
+type teamDeactivatedData struct {
+	TeamName               string   `json:"teamName"`
+	DeactivatedUserIDs     []string `json:"deactivatedUserIds"`
+	AffectedPullRequestIDs []string `json:"affectedPullRequestIds"`
+}
+
+// webhookFor maps a domain event to the outgoing webhook event and payload; an empty event type means the
+// domain event has no webhook. Initial reviewers are announced with the PR rather than one by one.
+func (s *WebhookService) webhookFor(tx *gorm.DB, event events.Event) (string, interface{}, error) {
+	switch e := event.(type) {
+	case events.PRCreated:
+		return models.EventReviewersAssigned, reviewersAssignedData{
+			PullRequest: e.PullRequest,
+			ReviewerIDs: e.PullRequest.AssignedReviewers,
+		}, nil
+	case events.ReviewerAssigned:
+		if e.Reason == models.ReviewerChangeInitial {
+			return "", nil, nil
+		}
+
+		pr, err := s.prRepository.FindByIDWithReviewersInTx(tx, e.PullRequestID)
+		if err != nil {
+			return "", nil, err
+		}
+		return models.EventReviewersAssigned, reviewersAssignedData{
+			PullRequest: pr.ToResponse(),
+			ReviewerIDs: []string{e.ReviewerID},
+		}, nil
+	case events.ReviewerReplaced:
+		return models.EventReviewerReplaced, reviewerReplacedData{
+			PullRequestID: e.PullRequestID,
+			OldReviewerID: e.OldReviewerID,
+			NewReviewerID: e.NewReviewerID,
+		}, nil
+	case events.PRMerged:
+		return models.EventPullRequestMerged, pullRequestMergedData{PullRequest: e.PullRequest}, nil
+	case events.TeamArchived:
+		return models.EventTeamArchived, teamArchivedData{
+			TeamName:             e.TeamName,
+			Policy:               e.Policy,
+			DeactivatedUserIDs:   e.DeactivatedUserIDs,
+			ClosedPullRequestIDs: e.ClosedPullRequestIDs,
+		}, nil
+	case events.TeamRenamed:
+		return models.EventTeamRenamed, teamRenamedData{OldName: e.OldName, NewName: e.NewName}, nil
+	case events.TeamDeactivated:
+		return models.EventTeamDeactivated, teamDeactivatedData{
+			TeamName:               e.TeamName,
+			DeactivatedUserIDs:     e.DeactivatedUserIDs,
+			AffectedPullRequestIDs: e.AffectedPullRequestIDs,
+		}, nil
+	case events.LoadImbalanced:
+		return models.EventLoadImbalanced, loadImbalancedData{
+			TeamName:  e.TeamName,
+			Gini:      e.Gini,
+			Threshold: e.Threshold,
+			Open:      e.Open,
+			Members:   e.Members,
+		}, nil
+	default:
+		return "", nil, nil
+	}
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"CodeRewievService/internal/events"
+	"CodeRewievService/internal/models"
+	"reflect"
+	"testing"
+)
+
+func TestWebhookFor(t *testing.T) {
+	pr := models.PullRequestDTO{PullRequestID: "pr-1", AssignedReviewers: []string{"u2", "u3"}}
+
+	tests := []struct {
+		name     string
+		event    events.Event
+		wantType string
+		wantData interface{}
+	}{
+		{
+			"new PR announces its initial reviewers",
+			events.PRCreated{PullRequest: pr},
+			models.EventReviewersAssigned,
+			reviewersAssignedData{PullRequest: pr, ReviewerIDs: []string{"u2", "u3"}},
+		},
+		{
+			"initial reviewer is not announced twice",
+			events.ReviewerAssigned{PullRequestID: "pr-1", ReviewerID: "u2", Reason: models.ReviewerChangeInitial},
+			"",
+			nil,
+		},
+		{
+			"replacement",
+			events.ReviewerReplaced{PullRequestID: "pr-1", OldReviewerID: "u2", NewReviewerID: "u4"},
+			models.EventReviewerReplaced,
+			reviewerReplacedData{PullRequestID: "pr-1", OldReviewerID: "u2", NewReviewerID: "u4"},
+		},
+		{
+			"merge",
+			events.PRMerged{PullRequest: pr},
+			models.EventPullRequestMerged,
+			pullRequestMergedData{PullRequest: pr},
+		},
+		{
+			"team rename",
+			events.TeamRenamed{OldName: "backend", NewName: "platform"},
+			models.EventTeamRenamed,
+			teamRenamedData{OldName: "backend", NewName: "platform"},
+		},
+		{
+			"user deactivation has no webhook",
+			events.UserDeactivated{UserID: "u2"},
+			"",
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventType, data, err := (&WebhookService{}).webhookFor(nil, tt.event)
+			if err != nil {
+				t.Fatalf("webhookFor() error = %v", err)
+			}
+			if eventType != tt.wantType || !reflect.DeepEqual(data, tt.wantData) {
+				t.Fatalf("webhookFor() = %q, %#v, want %q, %#v", eventType, data, tt.wantType, tt.wantData)
+			}
+		})
+	}
+}
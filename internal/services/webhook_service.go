@@ -0,0 +1,332 @@
+package services
+
+import (
+	"CodeRewievService/internal/events"
+	"CodeRewievService/internal/models"
+	"CodeRewievService/internal/repository"
+	"CodeRewievService/internal/webhooks"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	webhookDispatchBatchSize   = 50
+	defaultDeliveriesPageLimit = 100
+	maxDeliveriesPageLimit     = 1000
+	generatedSecretBytes       = 32
+
+	// webhookDeliveryLease must outlast a batch of attempts at the client timeout; an unfinished lease is
+	// retried after it expires.
+	webhookDeliveryLease = 15 * time.Minute
+)
+
+type webhookEnvelope struct {
+	Event      string      `json:"event"`
+	OccurredAt time.Time   `json:"occurredAt"`
+	Data       interface{} `json:"data"`
+}
+
+type WebhookService struct {
+	webhookRepository *repository.WebhookRepository
+	prRepository      *repository.PullRequestRepository
+	client            *webhooks.Client
+	retryPolicy       RetryPolicy
+	logger            *slog.Logger
+}
+
+func NewWebhookService(
+	webhookRepository *repository.WebhookRepository,
+	prRepository *repository.PullRequestRepository,
+	client *webhooks.Client,
+	retryPolicy RetryPolicy,
+	logger *slog.Logger,
+) *WebhookService {
+	return &WebhookService{
+		webhookRepository: webhookRepository,
+		prRepository:      prRepository,
+		client:            client,
+		retryPolicy:       retryPolicy,
+		logger:            logger,
+	}
+}
+
+// Subscribe registers a webhook; a generated secret is returned once, in this response only.
+func (s *WebhookService) Subscribe(req *models.RequestCreateWebhook) (*models.WebhookSubscriptionDTO, error) {
+	if err := s.validateSubscription(req); err != nil {
+		return nil, err
+	}
+
+	secret := req.Secret
+	generated := secret == ""
+	if generated {
+		var err error
+		if secret, err = generateSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	subscription := &models.WebhookSubscription{
+		URL:      req.URL,
+		Secret:   secret,
+		Events:   strings.Join(req.Events, ","),
+		IsActive: true,
+	}
+
+	if err := s.webhookRepository.CreateSubscription(subscription); err != nil {
+		return nil, err
+	}
+
+	dto := toWebhookSubscriptionDTO(subscription)
+	if generated {
+		dto.Secret = secret
+	}
+
+	return &dto, nil
+}
+
+func (s *WebhookService) ListSubscriptions() ([]models.WebhookSubscriptionDTO, error) {
+	subscriptions, err := s.webhookRepository.ListSubscriptions()
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]models.WebhookSubscriptionDTO, len(subscriptions))
+	for i := range subscriptions {
+		dtos[i] = toWebhookSubscriptionDTO(&subscriptions[i])
+	}
+
+	return dtos, nil
+}
+
+func (s *WebhookService) Unsubscribe(subscriptionID int64) error {
+	deleted, err := s.webhookRepository.DeleteSubscription(subscriptionID)
+	if err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		return models.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func (s *WebhookService) ListDeliveries(filter models.WebhookDeliveryFilter) ([]models.WebhookDelivery, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultDeliveriesPageLimit
+	}
+	if filter.Limit > maxDeliveriesPageLimit {
+		filter.Limit = maxDeliveriesPageLimit
+	}
+
+	return s.webhookRepository.ListDeliveries(filter)
+}
+
+// Redeliver queues a FAILED delivery again, or a DELIVERED one with force. A PENDING delivery may be in flight
+// and is never reset, so an event cannot be sent twice concurrently.
+func (s *WebhookService) Redeliver(deliveryID int64, force bool) error {
+	_, err := s.webhookRepository.FindDelivery(deliveryID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.ErrWebhookDeliveryNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	statuses := []string{models.WebhookDeliveryFailed}
+	if force {
+		statuses = append(statuses, models.WebhookDeliveryDelivered)
+	}
+
+	reset, err := s.webhookRepository.ResetDelivery(deliveryID, statuses, time.Now())
+	if err != nil {
+		return err
+	}
+	if !reset {
+		return models.ErrWebhookDeliveryNotFailed
+	}
+	return nil
+}
+
+// EnqueueInTx queues one delivery per subscriber of the event as part of the caller's transaction.
+// EnqueueEventInTx is the transactional bus subscriber of outgoing webhooks: it stores a delivery of the
+// matching webhook event for every subscription, in the transaction of the change, so none is lost on a crash.
+func (s *WebhookService) EnqueueEventInTx(_ context.Context, tx *gorm.DB, event events.Event) error {
+	eventType, data, err := s.webhookFor(tx, event)
+	if err != nil || eventType == "" {
+		return err
+	}
+
+	return s.enqueueInTx(tx, eventType, data)
+}
+
+func (s *WebhookService) enqueueInTx(tx *gorm.DB, eventType string, data interface{}) error {
+	subscriptions, err := s.webhookRepository.GetSubscribersInTx(tx, eventType)
+	if err != nil {
+		return err
+	}
+
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	payload, err := json.Marshal(webhookEnvelope{
+		Event:      eventType,
+		OccurredAt: now,
+		Data:       data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	deliveries := make([]models.WebhookDelivery, len(subscriptions))
+	for i, subscription := range subscriptions {
+		deliveries[i] = models.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventType:      eventType,
+			Payload:        payload,
+			Status:         models.WebhookDeliveryPending,
+			NextAttemptAt:  now,
+		}
+	}
+
+	return s.webhookRepository.EnqueueInTx(tx, deliveries)
+}
+
+// DeliverPending posts leased deliveries outside any transaction and records each attempt with its result on
+// its own, so a failure later in the batch neither loses the attempt log nor repeats delivered events.
+func (s *WebhookService) DeliverPending(ctx context.Context, now time.Time) (int, error) {
+	pending, err := s.webhookRepository.LeasePending(now, webhookDeliveryLease, webhookDispatchBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for i := range pending {
+		delivery := &pending[i]
+
+		attempt := s.attempt(ctx, delivery)
+		if s.applyAttemptResult(delivery, attempt, now) {
+			delivered++
+		}
+
+		if err := s.webhookRepository.RecordAttempt(delivery, attempt); err != nil {
+			return delivered, err
+		}
+	}
+
+	return delivered, nil
+}
+
+func (s *WebhookService) attempt(ctx context.Context, delivery *models.WebhookDelivery) *models.WebhookDeliveryAttempt {
+	start := time.Now()
+
+	status, err := s.client.Deliver(ctx, webhooks.Request{
+		URL:        delivery.Subscription.URL,
+		Secret:     delivery.Subscription.Secret,
+		EventType:  delivery.EventType,
+		DeliveryID: delivery.ID,
+		Body:       delivery.Payload,
+	})
+
+	attempt := &models.WebhookDeliveryAttempt{
+		DeliveryID:  delivery.ID,
+		AttemptedAt: start,
+		DurationMs:  time.Since(start).Milliseconds(),
+	}
+
+	if status != 0 {
+		attempt.ResponseStatus = &status
+	}
+	if err != nil {
+		attempt.Error = truncate(err.Error(), maxNotificationErrorLength)
+	}
+
+	return attempt
+}
+
+func (s *WebhookService) applyAttemptResult(
+	delivery *models.WebhookDelivery,
+	attempt *models.WebhookDeliveryAttempt,
+	now time.Time,
+) bool {
+	delivery.Attempts++
+
+	if attempt.Error == "" {
+		delivery.Status = models.WebhookDeliveryDelivered
+		delivery.DeliveredAt = &now
+		return true
+	}
+
+	if delivery.Attempts >= s.retryPolicy.MaxAttempts {
+		delivery.Status = models.WebhookDeliveryFailed
+		s.logger.Error("Webhook delivery failed permanently",
+			"deliveryID", delivery.ID,
+			"subscriptionID", delivery.SubscriptionID,
+			"attempts", delivery.Attempts,
+			"error", attempt.Error,
+		)
+		return false
+	}
+
+	delivery.NextAttemptAt = now.Add(s.retryPolicy.NextAttempt(delivery.Attempts))
+	s.logger.Warn("Webhook delivery failed, will retry",
+		"deliveryID", delivery.ID,
+		"subscriptionID", delivery.SubscriptionID,
+		"nextAttemptAt", delivery.NextAttemptAt,
+		"error", attempt.Error,
+	)
+	return false
+}
+
+func (s *WebhookService) validateSubscription(req *models.RequestCreateWebhook) error {
+	if req == nil {
+		return models.ErrInvalidWebhook
+	}
+
+	parsed, err := url.Parse(req.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http(s) URL", models.ErrInvalidWebhook)
+	}
+
+	if len(req.Events) == 0 {
+		return fmt.Errorf("%w: at least one event is required", models.ErrInvalidWebhook)
+	}
+
+	for _, event := range req.Events {
+		if !models.IsWebhookEventType(event) {
+			return fmt.Errorf("%w: unknown event %q", models.ErrInvalidWebhook, event)
+		}
+	}
+
+	return nil
+}
+
+func toWebhookSubscriptionDTO(subscription *models.WebhookSubscription) models.WebhookSubscriptionDTO {
+	return models.WebhookSubscriptionDTO{
+		ID:        subscription.ID,
+		URL:       subscription.URL,
+		Events:    strings.Split(subscription.Events, ","),
+		IsActive:  subscription.IsActive,
+		CreatedAt: subscription.CreatedAt,
+	}
+}
+
+func generateSecret() (string, error) {
+	buffer := make([]byte, generatedSecretBytes)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(buffer), nil
+}
@@ -0,0 +1,115 @@
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSign(t *testing.T) {
+	tests := []struct {
+		name   string
+		secret string
+		body   string
+		want   string
+	}{
+		{
+			"known vector",
+			"key",
+			"The quick brown fox jumps over the lazy dog",
+			"sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8",
+		},
+		{
+			"empty secret and body",
+			"",
+			"",
+			"sha256=b613679a0814d9ec772f95d778c35fc5ff1697c493715653c6c712144292c5ad",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sign(tt.secret, []byte(tt.body)); got != tt.want {
+				t.Fatalf("Sign() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"event":"pull_request.merged"}`)
+	signature := Sign("secret", body)
+
+	tests := []struct {
+		name      string
+		secret    string
+		body      []byte
+		signature string
+		want      bool
+	}{
+		{"valid signature", "secret", body, signature, true},
+		{"wrong secret", "other", body, signature, false},
+		{"tampered body", "secret", []byte(`{"event":"pull_request.created"}`), signature, false},
+		{"missing prefix", "secret", body, signature[len(signaturePrefix):], false},
+		{"uppercase hex", "secret", body, signaturePrefix + "F" + signature[len(signaturePrefix)+1:], false},
+		{"empty signature", "secret", body, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Verify(tt.secret, tt.body, tt.signature); got != tt.want {
+				t.Fatalf("Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	body := []byte(`{"event":"team.renamed"}`)
+
+	tests := []struct {
+		name       string
+		status     int
+		wantStatus int
+		wantErr    bool
+	}{
+		{"accepted", http.StatusAccepted, http.StatusAccepted, false},
+		{"redirect is a failure", http.StatusFound, http.StatusFound, true},
+		{"server error", http.StatusInternalServerError, http.StatusInternalServerError, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received, _ := io.ReadAll(r.Body)
+				if !Verify("secret", received, r.Header.Get(SignatureHeader)) {
+					t.Errorf("request signature does not match its body")
+				}
+				if got := r.Header.Get(EventHeader); got != "team.renamed" {
+					t.Errorf("%s = %q, want team.renamed", EventHeader, got)
+				}
+				if got := r.Header.Get(DeliveryHeader); got != "42" {
+					t.Errorf("%s = %q, want 42", DeliveryHeader, got)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			status, err := NewClient(nil).Deliver(context.Background(), Request{
+				URL:        server.URL,
+				Secret:     "secret",
+				EventType:  "team.renamed",
+				DeliveryID: 42,
+				Body:       body,
+			})
+			if status != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", status, tt.wantStatus)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
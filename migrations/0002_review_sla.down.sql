@@ -0,0 +1,6 @@
+-- Migration: 0002_review_sla.down.sql
+-- Rollback review SLA tables
+
+DROP TABLE IF EXISTS review_sla_breaches;
+DROP TABLE IF EXISTS team_review_slas;
+DROP TYPE IF EXISTS sla_action;
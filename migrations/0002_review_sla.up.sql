@@ -0,0 +1,26 @@
+-- Migration: 0002_review_sla.up.sql
+-- Per-team review SLAs and the log of detected SLA breaches
+
+CREATE TYPE sla_action AS ENUM ('NOTIFY', 'ADD_REVIEWER', 'REASSIGN');
+
+CREATE TABLE team_review_slas (
+    team_name VARCHAR(100) PRIMARY KEY REFERENCES teams(team_name) ON DELETE CASCADE,
+    first_review_minutes INTEGER NOT NULL CHECK (first_review_minutes > 0),
+    action sla_action NOT NULL DEFAULT 'NOTIFY',
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE review_sla_breaches (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(100) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    user_id VARCHAR(100) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    team_name VARCHAR(100) NOT NULL,
+    assigned_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    deadline_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    detected_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    action sla_action NOT NULL,
+    action_result TEXT NOT NULL DEFAULT '',
+    UNIQUE (pull_request_id, user_id, assigned_at)
+);
+
+CREATE INDEX idx_review_sla_breaches_team ON review_sla_breaches(team_name);
@@ -0,0 +1,5 @@
+-- Migration: 0003_business_calendar.down.sql
+-- Rollback business calendar tables
+
+DROP TABLE IF EXISTS team_holidays;
+DROP TABLE IF EXISTS team_calendars;
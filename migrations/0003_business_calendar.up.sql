@@ -0,0 +1,19 @@
+-- Migration: 0003_business_calendar.up.sql
+-- Per-team working calendar and holiday list used for business-time computations
+
+CREATE TABLE team_calendars (
+    team_name VARCHAR(100) PRIMARY KEY REFERENCES teams(team_name) ON DELETE CASCADE,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    work_days VARCHAR(32) NOT NULL DEFAULT '1,2,3,4,5',
+    work_start_minute INTEGER NOT NULL DEFAULT 540 CHECK (work_start_minute BETWEEN 0 AND 1440),
+    work_end_minute INTEGER NOT NULL DEFAULT 1080 CHECK (work_end_minute BETWEEN 0 AND 1440),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (work_start_minute < work_end_minute)
+);
+
+CREATE TABLE team_holidays (
+    team_name VARCHAR(100) NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+    holiday_date DATE NOT NULL,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    PRIMARY KEY (team_name, holiday_date)
+);
@@ -0,0 +1,7 @@
+-- Migration: 0004_notifications.down.sql
+-- Rollback notification tables and types
+
+DROP TABLE IF EXISTS notification_outbox;
+DROP TABLE IF EXISTS user_notification_preferences;
+DROP TYPE IF EXISTS notification_status;
+DROP TYPE IF EXISTS notification_channel;
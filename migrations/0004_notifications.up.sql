@@ -0,0 +1,32 @@
+-- Migration: 0004_notifications.up.sql
+-- Per-user notification channels and the transactional outbox of pending notifications
+
+CREATE TYPE notification_channel AS ENUM ('WEBHOOK', 'SLACK', 'EMAIL');
+CREATE TYPE notification_status AS ENUM ('PENDING', 'SENT', 'FAILED');
+
+CREATE TABLE user_notification_preferences (
+    user_id VARCHAR(100) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    channel notification_channel NOT NULL,
+    address TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    PRIMARY KEY (user_id, channel)
+);
+
+CREATE TABLE notification_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    event_type VARCHAR(100) NOT NULL,
+    recipient_id VARCHAR(100) NOT NULL,
+    channel notification_channel NOT NULL,
+    address TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    body TEXT NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    status notification_status NOT NULL DEFAULT 'PENDING',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_notification_outbox_pending ON notification_outbox(next_attempt_at) WHERE status = 'PENDING';
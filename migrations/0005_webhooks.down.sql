@@ -0,0 +1,7 @@
+-- Migration: 0005_webhooks.down.sql
+-- Rollback webhook tables and types
+
+DROP TABLE IF EXISTS webhook_delivery_attempts;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_subscriptions;
+DROP TYPE IF EXISTS webhook_delivery_status;
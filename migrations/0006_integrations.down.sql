@@ -0,0 +1,7 @@
+-- Migration: 0006_integrations.down.sql
+-- Rollback integration tables; CLOSED pull requests are reopened because enum values cannot be dropped
+
+DROP TABLE IF EXISTS integration_deliveries;
+DROP TABLE IF EXISTS external_user_mappings;
+
+UPDATE pull_requests SET status = 'OPEN' WHERE status = 'CLOSED';
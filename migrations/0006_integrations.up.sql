@@ -0,0 +1,23 @@
+-- Migration: 0006_integrations.up.sql
+-- Inbound VCS integrations: closed PR status, external login mapping and processed delivery log
+
+ALTER TYPE pull_request_status ADD VALUE IF NOT EXISTS 'CLOSED';
+
+CREATE TABLE external_user_mappings (
+    provider VARCHAR(32) NOT NULL,
+    external_login VARCHAR(255) NOT NULL,
+    user_id VARCHAR(100) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    PRIMARY KEY (provider, external_login)
+);
+
+CREATE INDEX idx_external_user_mappings_user ON external_user_mappings(provider, user_id);
+
+CREATE TABLE integration_deliveries (
+    provider VARCHAR(32) NOT NULL,
+    delivery_id VARCHAR(255) NOT NULL,
+    event VARCHAR(100) NOT NULL,
+    action VARCHAR(100) NOT NULL DEFAULT '',
+    result TEXT NOT NULL DEFAULT '',
+    received_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (provider, delivery_id)
+);
@@ -0,0 +1,4 @@
+-- Migration: 0007_audit_log.down.sql
+-- Rollback audit trail
+
+DROP TABLE IF EXISTS audit_log;
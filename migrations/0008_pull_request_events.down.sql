@@ -0,0 +1,4 @@
+-- Migration: 0008_pull_request_events.down.sql
+-- Rollback pull request timeline
+
+DROP TABLE IF EXISTS pull_request_events;
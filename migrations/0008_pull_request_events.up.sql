@@ -0,0 +1,34 @@
+-- Migration: 0008_pull_request_events.up.sql
+-- Append-only timeline of pull request lifecycle and reviewer changes; pull_request_reviewers keeps only
+-- the current assignment. Existing pull requests are backfilled from what the current tables still know.
+
+CREATE TABLE pull_request_events (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(100) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    event_type VARCHAR(50) NOT NULL,
+    old_reviewer_id VARCHAR(100) NOT NULL DEFAULT '',
+    new_reviewer_id VARCHAR(100) NOT NULL DEFAULT '',
+    reason VARCHAR(50) NOT NULL DEFAULT '',
+    actor VARCHAR(255) NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_pull_request_events_pr ON pull_request_events(pull_request_id, occurred_at, id);
+
+INSERT INTO pull_request_events (pull_request_id, event_type, actor, occurred_at)
+SELECT pull_request_id, 'created', 'unknown', COALESCE(created_at, CURRENT_TIMESTAMP)
+FROM pull_requests;
+
+INSERT INTO pull_request_events (pull_request_id, event_type, new_reviewer_id, actor, occurred_at)
+SELECT pull_request_id, 'reviewer_assigned', user_id, 'unknown', COALESCE(assigned_at, CURRENT_TIMESTAMP)
+FROM pull_request_reviewers;
+
+INSERT INTO pull_request_events (pull_request_id, event_type, actor, occurred_at)
+SELECT pull_request_id, 'merged', 'unknown', merged_at
+FROM pull_requests
+WHERE status = 'MERGED' AND merged_at IS NOT NULL;
+
+INSERT INTO pull_request_events (pull_request_id, event_type, actor, occurred_at)
+SELECT pull_request_id, 'closed', 'unknown', COALESCE(updated_at, CURRENT_TIMESTAMP)
+FROM pull_requests
+WHERE status = 'CLOSED';
@@ -0,0 +1,4 @@
+-- Migration: 0009_team_membership.down.sql
+-- Rollback nullable team membership; fails while users without a team exist, add them to a team first
+
+ALTER TABLE users ALTER COLUMN team_name SET NOT NULL;
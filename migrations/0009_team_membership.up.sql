@@ -0,0 +1,4 @@
+-- Migration: 0009_team_membership.up.sql
+-- Users removed from their team stay in place (they may author or have reviewed PRs) without a team
+
+ALTER TABLE users ALTER COLUMN team_name DROP NOT NULL;
@@ -0,0 +1,7 @@
+-- Migration: 0010_multi_team_membership.down.sql
+-- Rollback many-to-many membership; only primary teams survive in users.team_name
+
+DROP INDEX IF EXISTS idx_pull_request_reviewers_team;
+ALTER TABLE pull_request_reviewers DROP COLUMN IF EXISTS team_name;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS team_name;
+DROP TABLE IF EXISTS team_memberships;
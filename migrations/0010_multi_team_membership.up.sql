@@ -0,0 +1,30 @@
+-- Migration: 0010_multi_team_membership.up.sql
+-- Many-to-many team membership. users.team_name stays as the optional primary team; pull requests may name
+-- the repository team reviewers are drawn from, and each assignment records the team it counts towards.
+
+CREATE TABLE team_memberships (
+    user_id VARCHAR(100) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    team_name VARCHAR(100) NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+    is_primary BOOLEAN NOT NULL DEFAULT false,
+    joined_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, team_name)
+);
+
+CREATE UNIQUE INDEX idx_team_memberships_primary ON team_memberships(user_id) WHERE is_primary;
+CREATE INDEX idx_team_memberships_team ON team_memberships(team_name);
+
+INSERT INTO team_memberships (user_id, team_name, is_primary)
+SELECT user_id, team_name, true
+FROM users
+WHERE team_name IS NOT NULL;
+
+ALTER TABLE pull_requests ADD COLUMN team_name VARCHAR(100) NOT NULL DEFAULT '';
+
+ALTER TABLE pull_request_reviewers ADD COLUMN team_name VARCHAR(100) NOT NULL DEFAULT '';
+
+UPDATE pull_request_reviewers prr
+SET team_name = u.team_name
+FROM users u
+WHERE u.user_id = prr.user_id AND u.team_name IS NOT NULL;
+
+CREATE INDEX idx_pull_request_reviewers_team ON pull_request_reviewers(team_name);
@@ -0,0 +1,6 @@
+-- Migration: 0011_team_hierarchy.down.sql
+-- Rollback team hierarchy
+
+DROP INDEX IF EXISTS idx_teams_parent;
+ALTER TABLE teams DROP CONSTRAINT IF EXISTS teams_parent_not_self;
+ALTER TABLE teams DROP COLUMN IF EXISTS parent_team;
@@ -0,0 +1,7 @@
+-- Migration: 0011_team_hierarchy.up.sql
+-- Optional parent team (department -> team -> squad); removing a parent detaches its sub-teams
+
+ALTER TABLE teams ADD COLUMN parent_team VARCHAR(100) REFERENCES teams(team_name) ON DELETE SET NULL;
+ALTER TABLE teams ADD CONSTRAINT teams_parent_not_self CHECK (parent_team <> team_name);
+
+CREATE INDEX idx_teams_parent ON teams(parent_team);
@@ -0,0 +1,8 @@
+-- Migration: 0012_team_archive.down.sql
+-- Rollback team archival
+
+ALTER TABLE users DROP CONSTRAINT users_team_name_fkey;
+ALTER TABLE users ADD CONSTRAINT users_team_name_fkey
+    FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE;
+
+ALTER TABLE teams DROP COLUMN IF EXISTS archived_at;
@@ -0,0 +1,9 @@
+-- Migration: 0012_team_archive.up.sql
+-- Archived teams stay in place with their history. Deleting a team no longer cascades to its users:
+-- they only lose their primary team, their PRs and reviews stay.
+
+ALTER TABLE teams ADD COLUMN archived_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE users DROP CONSTRAINT users_team_name_fkey;
+ALTER TABLE users ADD CONSTRAINT users_team_name_fkey
+    FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE SET NULL;
@@ -0,0 +1,9 @@
+-- Migration: 0013_user_profile.down.sql
+-- Rollback user profile attributes
+
+DROP INDEX IF EXISTS idx_users_is_active;
+
+ALTER TABLE users DROP COLUMN IF EXISTS timezone;
+ALTER TABLE users DROP COLUMN IF EXISTS chat_handle;
+ALTER TABLE users DROP COLUMN IF EXISTS display_name;
+ALTER TABLE users DROP COLUMN IF EXISTS email;
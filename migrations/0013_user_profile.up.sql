@@ -0,0 +1,9 @@
+-- Migration: 0013_user_profile.up.sql
+-- Profile attributes used by notifications and chat integrations. Empty values mean "not set".
+
+ALTER TABLE users ADD COLUMN email VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN display_name VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN chat_handle VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT '';
+
+CREATE INDEX idx_users_is_active ON users(is_active);
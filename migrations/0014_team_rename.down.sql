@@ -0,0 +1,28 @@
+-- Migration: 0014_team_rename.down.sql
+-- Rollback cascading team renames
+
+DROP INDEX IF EXISTS idx_pull_requests_team;
+
+ALTER TABLE team_holidays DROP CONSTRAINT team_holidays_team_name_fkey;
+ALTER TABLE team_holidays ADD CONSTRAINT team_holidays_team_name_fkey
+    FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE;
+
+ALTER TABLE team_calendars DROP CONSTRAINT team_calendars_team_name_fkey;
+ALTER TABLE team_calendars ADD CONSTRAINT team_calendars_team_name_fkey
+    FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE;
+
+ALTER TABLE team_review_slas DROP CONSTRAINT team_review_slas_team_name_fkey;
+ALTER TABLE team_review_slas ADD CONSTRAINT team_review_slas_team_name_fkey
+    FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE;
+
+ALTER TABLE team_memberships DROP CONSTRAINT team_memberships_team_name_fkey;
+ALTER TABLE team_memberships ADD CONSTRAINT team_memberships_team_name_fkey
+    FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE;
+
+ALTER TABLE teams DROP CONSTRAINT teams_parent_team_fkey;
+ALTER TABLE teams ADD CONSTRAINT teams_parent_team_fkey
+    FOREIGN KEY (parent_team) REFERENCES teams(team_name) ON DELETE SET NULL;
+
+ALTER TABLE users DROP CONSTRAINT users_team_name_fkey;
+ALTER TABLE users ADD CONSTRAINT users_team_name_fkey
+    FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE SET NULL;
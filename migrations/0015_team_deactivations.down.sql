@@ -0,0 +1,6 @@
+-- Migration: 0015_team_deactivations.down.sql
+-- Rollback team deactivation snapshots
+
+DROP TABLE IF EXISTS team_deactivation_reviews;
+DROP TABLE IF EXISTS team_deactivation_users;
+DROP TABLE IF EXISTS team_deactivations;
@@ -0,0 +1,29 @@
+-- Migration: 0015_team_deactivations.up.sql
+-- Snapshot of every mass team deactivation, so a mistaken one can be reverted: the users it deactivated and the
+-- review assignments it removed, with the replacement chosen at the time if there was one.
+
+CREATE TABLE team_deactivations (
+    id BIGSERIAL PRIMARY KEY,
+    team_name VARCHAR(100) NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE ON UPDATE CASCADE,
+    actor VARCHAR(255) NOT NULL,
+    deactivated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    restored_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_team_deactivations_team ON team_deactivations(team_name);
+
+CREATE TABLE team_deactivation_users (
+    deactivation_id BIGINT NOT NULL REFERENCES team_deactivations(id) ON DELETE CASCADE,
+    user_id VARCHAR(100) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    PRIMARY KEY (deactivation_id, user_id)
+);
+
+CREATE TABLE team_deactivation_reviews (
+    deactivation_id BIGINT NOT NULL REFERENCES team_deactivations(id) ON DELETE CASCADE,
+    pull_request_id VARCHAR(100) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    user_id VARCHAR(100) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    team_name VARCHAR(100) NOT NULL DEFAULT '',
+    assigned_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    replaced_by VARCHAR(100) NOT NULL DEFAULT '',
+    PRIMARY KEY (deactivation_id, pull_request_id, user_id)
+);
@@ -0,0 +1,5 @@
+-- Migration: 0016_scheduled_jobs.down.sql
+-- Rollback scheduled jobs
+
+DROP TABLE IF EXISTS scheduled_jobs;
+DROP TYPE IF EXISTS scheduled_job_status;
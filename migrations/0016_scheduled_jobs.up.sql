@@ -0,0 +1,22 @@
+-- Migration: 0016_scheduled_jobs.up.sql
+-- User activation changes and team deactivations scheduled for a future time, executed by a background job
+
+CREATE TYPE scheduled_job_status AS ENUM ('PENDING', 'SUCCEEDED', 'FAILED', 'CANCELLED');
+
+CREATE TABLE scheduled_jobs (
+    id BIGSERIAL PRIMARY KEY,
+    job_type VARCHAR(50) NOT NULL,
+    user_id VARCHAR(100) NOT NULL DEFAULT '',
+    team_name VARCHAR(100) NOT NULL DEFAULT '',
+    is_active BOOLEAN NOT NULL DEFAULT false,
+    run_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    status scheduled_job_status NOT NULL DEFAULT 'PENDING',
+    actor VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP WITH TIME ZONE,
+    result JSONB,
+    error TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_scheduled_jobs_pending ON scheduled_jobs(run_at) WHERE status = 'PENDING';
+CREATE INDEX idx_scheduled_jobs_created ON scheduled_jobs(created_at);
@@ -0,0 +1,4 @@
+-- Migration: 0017_review_load_imbalance.down.sql
+-- Rollback review load imbalance alerts
+
+DROP TABLE IF EXISTS team_load_imbalance_alerts;
@@ -0,0 +1,9 @@
+-- Migration: 0017_review_load_imbalance.up.sql
+-- Teams whose review load is currently reported as imbalanced, so an alert is raised once per crossing
+
+CREATE TABLE team_load_imbalance_alerts (
+    team_name VARCHAR(100) PRIMARY KEY REFERENCES teams(team_name) ON DELETE CASCADE ON UPDATE CASCADE,
+    gini_coefficient DOUBLE PRECISION NOT NULL,
+    threshold DOUBLE PRECISION NOT NULL,
+    raised_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
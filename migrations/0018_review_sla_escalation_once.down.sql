@@ -0,0 +1,4 @@
+-- Migration: 0018_review_sla_escalation_once.down.sql
+-- Rollback once-per-PR SLA escalation
+
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS sla_escalated_at;
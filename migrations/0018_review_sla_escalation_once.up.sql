@@ -0,0 +1,13 @@
+-- Migration: 0018_review_sla_escalation_once.up.sql
+-- An SLA is escalated once per PR: assignments made afterwards, including by the escalation itself, do not re-arm it
+
+ALTER TABLE pull_requests ADD COLUMN sla_escalated_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE pull_requests pr
+SET sla_escalated_at = breaches.detected_at
+FROM (
+    SELECT pull_request_id, MIN(detected_at) AS detected_at
+    FROM review_sla_breaches
+    GROUP BY pull_request_id
+) breaches
+WHERE breaches.pull_request_id = pr.pull_request_id;
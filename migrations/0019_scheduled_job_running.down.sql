@@ -0,0 +1,6 @@
+-- Migration: 0019_scheduled_job_running.down.sql
+-- Rollback the RUNNING status; interrupted jobs are marked failed because enum values cannot be dropped
+
+UPDATE scheduled_jobs
+SET status = 'FAILED', finished_at = CURRENT_TIMESTAMP, error = 'interrupted while running'
+WHERE status = 'RUNNING';
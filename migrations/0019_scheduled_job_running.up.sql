@@ -0,0 +1,4 @@
+-- Migration: 0019_scheduled_job_running.up.sql
+-- Claimed scheduled jobs are marked RUNNING before they execute, outside the transaction that claimed them
+
+ALTER TYPE scheduled_job_status ADD VALUE IF NOT EXISTS 'RUNNING';
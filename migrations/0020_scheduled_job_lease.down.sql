@@ -0,0 +1,6 @@
+-- Migration: 0020_scheduled_job_lease.down.sql
+-- Rollback the scheduled job lease
+
+DROP INDEX IF EXISTS idx_scheduled_jobs_running;
+
+ALTER TABLE scheduled_jobs DROP COLUMN IF EXISTS claimed_at;
@@ -0,0 +1,6 @@
+-- Migration: 0020_scheduled_job_lease.up.sql
+-- Running scheduled jobs hold a lease from the claim time; jobs still running after it are failed as interrupted
+
+ALTER TABLE scheduled_jobs ADD COLUMN claimed_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_scheduled_jobs_running ON scheduled_jobs(claimed_at) WHERE status = 'RUNNING';
@@ -0,0 +1,4 @@
+-- Migration: 0021_review_verdicts.down.sql
+-- Rollback reviewer verdicts
+
+DROP TABLE IF EXISTS review_verdicts;
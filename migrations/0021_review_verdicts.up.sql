@@ -0,0 +1,16 @@
+-- Migration: 0021_review_verdicts.up.sql
+-- Reviewer verdicts on pull requests; each keeps the assignment time it answers, so review turnaround outlives
+-- the assignment row. team_name is the team the review was assigned on behalf of, without a constraint.
+
+CREATE TABLE review_verdicts (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(100) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    user_id VARCHAR(100) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    team_name VARCHAR(100) NOT NULL DEFAULT '',
+    verdict VARCHAR(30) NOT NULL,
+    assigned_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    submitted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_review_verdicts_team ON review_verdicts(team_name, submitted_at);
+CREATE INDEX idx_review_verdicts_assignment ON review_verdicts(pull_request_id, user_id, assigned_at);